@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrEmptyToken = errors.New("the provided token is empty")
+)
+
+var (
+	readConfigErr = "failed to read config: %s"
+	saveConfigErr = "failed to save config: %s"
+	configDirErr  = "failed to find the config directory: %s. Set $XDG_CONFIG_HOME or $HOME on Linux, $HOME on macOS or %%AppData%% on Windows to choose where the token is stored"
+)
+
+const TokenEnvName = "GLOBALPING_TOKEN"
+
+var CONFIG_PATH string
+
+// Per-user configuration persisted between sessions
+type Config struct {
+	Token string `json:"token,omitempty"`
+}
+
+func (r *Root) initAuth() {
+	authCmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Authenticate with the Globalping API",
+		Long: `Authenticate with the Globalping API to get higher limits.
+The token is stored in a per-user config file. Alternatively, the token can be provided using the GLOBALPING_TOKEN environment variable, which takes precedence over the stored token.`,
+	}
+
+	loginCmd := &cobra.Command{
+		RunE:  r.RunAuthLogin,
+		Use:   "login",
+		Short: "Store an API token to authenticate future measurements",
+		Long: `Store an API token to authenticate future measurements.
+If the --token flag is not provided, the token is read from the standard input.
+
+Examples:
+  # Log in with a token
+  auth login --token <token>
+
+  # Log in with a token read from a file
+  auth login < token.txt`,
+	}
+	loginCmd.Flags().StringVar(&r.ctx.Token, "token", r.ctx.Token, "The API token to store")
+
+	logoutCmd := &cobra.Command{
+		RunE:  r.RunAuthLogout,
+		Use:   "logout",
+		Short: "Remove the stored API token",
+	}
+
+	statusCmd := &cobra.Command{
+		RunE:  r.RunAuthStatus,
+		Use:   "status",
+		Short: "Show the current authentication status",
+	}
+
+	authCmd.AddCommand(loginCmd, logoutCmd, statusCmd)
+	r.Cmd.AddCommand(authCmd)
+}
+
+func (r *Root) RunAuthLogin(cmd *cobra.Command, args []string) error {
+	token := strings.TrimSpace(r.ctx.Token)
+	if token == "" {
+		r.printer.Print("Please enter your token: ")
+		line, err := bufio.NewReader(r.printer.InReader).ReadString('\n')
+		if err != nil && line == "" {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("failed to read token: %s", err)
+		}
+		r.printer.Println()
+		token = strings.TrimSpace(line)
+	}
+	if token == "" {
+		return ErrEmptyToken
+	}
+
+	cmd.SilenceUsage = true
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	config.Token = token
+	err = saveConfig(config)
+	if err != nil {
+		return err
+	}
+
+	configPath, _ := getConfigPath()
+	r.printer.Printf("Token saved to %s\n", configPath)
+	if os.Getenv(TokenEnvName) != "" {
+		r.printer.Printf("Warning: the %s environment variable is set and takes precedence over the stored token\n", TokenEnvName)
+	}
+	return nil
+}
+
+func (r *Root) RunAuthLogout(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if config.Token == "" {
+		r.printer.Println("You are not logged in")
+		return nil
+	}
+	config.Token = ""
+	err = saveConfig(config)
+	if err != nil {
+		return err
+	}
+	r.printer.Println("Logged out successfully")
+	return nil
+}
+
+func (r *Root) RunAuthStatus(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	token := os.Getenv(TokenEnvName)
+	if token != "" {
+		r.printer.Printf("Authenticated using the %s environment variable (%s)\n", TokenEnvName, maskToken(token))
+		return nil
+	}
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if config.Token == "" {
+		r.printer.Println("Not logged in")
+		return nil
+	}
+	configPath, _ := getConfigPath()
+	r.printer.Printf("Logged in using the token stored in %s (%s)\n", configPath, maskToken(config.Token))
+	return nil
+}
+
+// Returns the token used to authenticate API requests, if any.
+// The environment variable takes precedence over the stored token.
+func getToken() (string, error) {
+	token := os.Getenv(TokenEnvName)
+	if token != "" {
+		return token, nil
+	}
+	config, err := loadConfig()
+	if err != nil {
+		return "", err
+	}
+	return config.Token, nil
+}
+
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:4] + strings.Repeat("*", len(token)-8) + token[len(token)-4:]
+}
+
+func loadConfig() (*Config, error) {
+	config := &Config{}
+	configPath, err := getConfigPath()
+	if err != nil {
+		// Without a config directory nothing can have been stored
+		return config, nil
+	}
+	b, err := os.ReadFile(configPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return config, nil
+		}
+		return nil, fmt.Errorf(readConfigErr, err)
+	}
+	err = json.Unmarshal(b, config)
+	if err != nil {
+		return nil, fmt.Errorf(readConfigErr, err)
+	}
+	return config, nil
+}
+
+func saveConfig(config *Config) error {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(configPath), 0700)
+	if err != nil {
+		return fmt.Errorf(saveConfigErr, err)
+	}
+	b, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf(saveConfigErr, err)
+	}
+	// The config may contain secrets, keep it readable only by the owner
+	err = os.WriteFile(configPath, b, 0600)
+	if err != nil {
+		return fmt.Errorf(saveConfigErr, err)
+	}
+	return nil
+}
+
+func getConfigPath() (string, error) {
+	if CONFIG_PATH != "" {
+		return CONFIG_PATH, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf(configDirErr, err)
+	}
+	CONFIG_PATH = filepath.Join(dir, "globalping", "config.json")
+	return CONFIG_PATH, nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func configCleanup(t *testing.T) {
+	CONFIG_PATH = filepath.Join(t.TempDir(), "globalping", "config.json")
+	t.Setenv(TokenEnvName, "")
+	t.Cleanup(func() {
+		CONFIG_PATH = ""
+	})
+}
+
+func Test_Execute_Auth_Login_Logout(t *testing.T) {
+	configCleanup(t)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, createDefaultContext("auth"), nil, nil, nil, nil)
+	os.Args = []string{"globalping", "auth", "login", "--token", "abcd1234efgh5678"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "Token saved to "+CONFIG_PATH+"\n", w.String())
+
+	b, err := os.ReadFile(CONFIG_PATH)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"token\": \"abcd1234efgh5678\"\n}", string(b))
+
+	fi, err := os.Stat(CONFIG_PATH)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	token, err := getToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd1234efgh5678", token)
+
+	w.Reset()
+	root = NewRoot(printer, createDefaultContext("auth"), nil, nil, nil, nil)
+	os.Args = []string{"globalping", "auth", "status"}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "Logged in using the token stored in "+CONFIG_PATH+" (abcd********5678)\n", w.String())
+
+	w.Reset()
+	root = NewRoot(printer, createDefaultContext("auth"), nil, nil, nil, nil)
+	os.Args = []string{"globalping", "auth", "logout"}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "Logged out successfully\n", w.String())
+
+	token, err = getToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "", token)
+
+	w.Reset()
+	root = NewRoot(printer, createDefaultContext("auth"), nil, nil, nil, nil)
+	os.Args = []string{"globalping", "auth", "status"}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "Not logged in\n", w.String())
+}
+
+func Test_Execute_Auth_Login_Stdin(t *testing.T) {
+	configCleanup(t)
+
+	reader := bytes.NewReader([]byte("  tok3n  \n"))
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(reader, w, w)
+	root := NewRoot(printer, createDefaultContext("auth"), nil, nil, nil, nil)
+	os.Args = []string{"globalping", "auth", "login"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "Please enter your token: \nToken saved to "+CONFIG_PATH+"\n", w.String())
+
+	token, err := getToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "tok3n", token)
+}
+
+func Test_Execute_Auth_Login_Empty(t *testing.T) {
+	configCleanup(t)
+
+	reader := bytes.NewReader([]byte("\n"))
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(reader, w, w)
+	root := NewRoot(printer, createDefaultContext("auth"), nil, nil, nil, nil)
+	os.Args = []string{"globalping", "auth", "login"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.ErrorIs(t, err, ErrEmptyToken)
+
+	_, err = os.Stat(CONFIG_PATH)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func Test_Execute_Auth_Status_Env(t *testing.T) {
+	configCleanup(t)
+	t.Setenv(TokenEnvName, "envtoken")
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, createDefaultContext("auth"), nil, nil, nil, nil)
+	os.Args = []string{"globalping", "auth", "status"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "Authenticated using the GLOBALPING_TOKEN environment variable (********)\n", w.String())
+
+	token, err := getToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "envtoken", token)
+}
+
+func Test_Execute_Auth_Login_No_Config_Dir(t *testing.T) {
+	CONFIG_PATH = ""
+	t.Setenv(TokenEnvName, "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+	t.Setenv("AppData", "")
+	t.Setenv("home", "")
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, createDefaultContext("auth"), nil, nil, nil, nil)
+	os.Args = []string{"globalping", "auth", "login", "--token", "abcd1234efgh5678"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.ErrorContains(t, err, "failed to find the config directory: ")
+	assert.Equal(t, "Error: "+err.Error()+"\n", w.String())
+	assert.Empty(t, CONFIG_PATH)
+
+	token, err := getToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "", token)
+}
@@ -48,6 +48,11 @@ func (r *Root) updateContext(cmd string, args []string) error {
 		r.ctx.Resolver = targetQuery.Resolver
 	}
 
+	return r.updateCIMode()
+}
+
+// Enables the CI mode if running in CI or if stdout is not a terminal
+func (r *Root) updateCIMode() error {
 	// Check env for CI
 	if os.Getenv("CI") != "" {
 		r.ctx.CIMode = true
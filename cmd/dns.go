@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"github.com/jsdelivr/globalping-cli/globalping"
-	"github.com/jsdelivr/globalping-cli/view"
 	"github.com/spf13/cobra"
 )
 
@@ -48,6 +47,15 @@ Using the dig format @resolver. For example:
   # Resolve jsdelivr.com from a probe that is from the AWS network and is located in Montreal with latency output
   dns jsdelivr.com from aws+montreal --latency
 
+  # Resolve jsdelivr.com from 3 probes and fail if any of them doesn't return the 1.2.3.4 address
+  dns jsdelivr.com --limit 3 --expect-status NOERROR --expect-dns-answer 1.2.3.4 --ci
+
+  # Resolve jsdelivr.com from 3 probes in Europe continuously and track the answer changes
+  dns jsdelivr.com from Europe --limit 3 --infinite
+
+  # Resolve jsdelivr.com from 3 probes in Europe and compare the answers in a table
+  dns jsdelivr.com from Europe --limit 3 --table
+
   # Resolve jsdelivr.com from a probe in ASN 123 with json output
   dns jsdelivr.com from 123 --json`,
 	}
@@ -59,6 +67,10 @@ Using the dig format @resolver. For example:
 	flags.StringVar(&r.ctx.Resolver, "resolver", r.ctx.Resolver, "Resolver is the hostname or IP address of the name server to use (default empty)")
 	flags.StringVar(&r.ctx.QueryType, "type", r.ctx.QueryType, "Specifies the type of DNS query to perform (default \"A\")")
 	flags.BoolVar(&r.ctx.Trace, "trace", r.ctx.Trace, "Toggle tracing of the delegation path from the root name servers (default false)")
+	flags.StringVar(&r.ctx.Assertions.ExpectStatus, "expect-status", r.ctx.Assertions.ExpectStatus, "Fail if the status code of any probe differs from the given one, e.g. NOERROR")
+	flags.BoolVar(&r.ctx.Infinite, "infinite", r.ctx.Infinite, "Keep resolving the target with the same probes until stopped, showing the resolution times and answer changes of every probe (default false)")
+	flags.StringVar(&r.ctx.Assertions.ExpectDNSAnswer, "expect-dns-answer", r.ctx.Assertions.ExpectDNSAnswer, "Fail if the given value is missing from the answers of any probe, e.g. 1.2.3.4")
+	r.addInfiniteFlags(dnsCmd)
 
 	r.Cmd.AddCommand(dnsCmd)
 }
@@ -69,6 +81,11 @@ func (r *Root) RunDNS(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	err = r.validateInfinite("--expect-status and --expect-dns-answer")
+	if err != nil {
+		return err
+	}
+
 	defer r.UpdateHistory()
 	r.ctx.RecordToSession = true
 
@@ -93,29 +110,21 @@ func (r *Root) RunDNS(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	res, showHelp, err := r.client.CreateMeasurement(opts)
+	ctx, cancel := r.contextWithCancel(cmd.Context())
+	defer cancel()
+
+	if r.ctx.Infinite {
+		return r.runInfinite(ctx, opts)
+	}
+
+	hm, err := r.createMeasurement(ctx, opts)
 	if err != nil {
-		if !showHelp {
-			cmd.SilenceUsage = true
-		}
 		return err
 	}
 
-	r.ctx.MeasurementsCreated++
-	hm := &view.HistoryItem{
-		Id:        res.ID,
-		Status:    globalping.StatusInProgress,
-		StartedAt: r.time.Now(),
-	}
-	r.ctx.History.Push(hm)
-	if r.ctx.RecordToSession {
-		r.ctx.RecordToSession = false
-		err := saveIdToSession(res.ID)
-		if err != nil {
-			r.printer.Printf("Warning: %s\n", err)
-		}
+	err = r.viewer.Output(ctx, hm.Id, opts)
+	if err != nil {
+		cmd.SilenceUsage = true
 	}
-
-	r.viewer.Output(res.ID, opts)
-	return nil
+	return err
 }
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/jsdelivr/globalping-cli/globalping"
 	"github.com/jsdelivr/globalping-cli/mocks"
@@ -32,10 +34,10 @@ func Test_Execute_DNS_Default(t *testing.T) {
 	expectedResponse := createDefaultMeasurementCreateResponse()
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().CreateMeasurement(expectedOpts).Times(1).Return(expectedResponse, false, nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Times(1).Return(expectedResponse, nil)
 
 	viewerMock := mocks.NewMockViewer(ctrl)
-	viewerMock.EXPECT().Output(measurementID1, expectedOpts).Times(1).Return(nil)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).Times(1).Return(nil)
 
 	timeMock := mocks.NewMockTime(ctrl)
 	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
@@ -82,3 +84,140 @@ func Test_Execute_DNS_Default(t *testing.T) {
 	)
 	assert.Equal(t, expectedHistory, string(b))
 }
+
+func Test_Execute_DNS_Infinite(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts1 := createDefaultMeasurementCreate("dns")
+	expectedOpts1.Options.Query = &globalping.QueryOptions{}
+	expectedOpts2 := createDefaultMeasurementCreate("dns")
+	expectedOpts2.Options.Query = &globalping.QueryOptions{}
+	expectedOpts2.Locations[0].Magic = measurementID1
+
+	expectedResponse2 := createDefaultMeasurementCreateResponse()
+	expectedResponse2.ID = measurementID2
+
+	gbMock := mocks.NewMockClient(ctrl)
+	createCall1 := gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts1).Return(createDefaultMeasurementCreateResponse(), nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts2).Return(expectedResponse2, nil).After(createCall1)
+
+	inProgressMeasurement := createDefaultMeasurement("dns")
+	inProgressMeasurement.Status = globalping.StatusInProgress
+	expectedMeasurement1 := createDefaultMeasurement("dns")
+	expectedMeasurement2 := createDefaultMeasurement("dns")
+	expectedMeasurement2.ID = measurementID2
+
+	// The next measurement is only created once the previous one is finished
+	getCall1 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(inProgressMeasurement, nil)
+	getCall2 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement1, nil).After(getCall1)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID2).Return(expectedMeasurement2, nil).After(getCall2)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("dns")
+	ctx.History = view.NewHistoryBuffer(10)
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+	root := NewRoot(printer, ctx, viewerMock, timeMock, gbMock, nil)
+
+	outputCall1 := viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement1).Return(nil)
+	viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement2).DoAndReturn(func(_ context.Context, m *globalping.Measurement) error {
+		root.cancel <- syscall.SIGINT
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}).After(outputCall1)
+	viewerMock.EXPECT().OutputSummary().Times(1)
+
+	os.Args = []string{"globalping", "dns", "jsdelivr.com", "--infinite", "from", "Berlin"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "", w.String())
+
+	assert.Equal(t, 2, ctx.MeasurementsCreated)
+	assert.Equal(t, globalping.StatusFinished, ctx.History.Find(measurementID1).Status)
+	assert.Equal(t, globalping.StatusFinished, ctx.History.Find(measurementID2).Status)
+}
+
+func Test_Execute_DNS_Infinite_Interval_Duration(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts1 := createDefaultMeasurementCreate("dns")
+	expectedOpts1.Options.Query = &globalping.QueryOptions{}
+	expectedOpts2 := createDefaultMeasurementCreate("dns")
+	expectedOpts2.Options.Query = &globalping.QueryOptions{}
+	expectedOpts2.Locations[0].Magic = measurementID1
+
+	expectedResponse2 := createDefaultMeasurementCreateResponse()
+	expectedResponse2.ID = measurementID2
+
+	gbMock := mocks.NewMockClient(ctrl)
+	createCall1 := gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts1).Return(createDefaultMeasurementCreateResponse(), nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts2).Return(expectedResponse2, nil).After(createCall1)
+
+	expectedMeasurement1 := createDefaultMeasurement("dns")
+	expectedMeasurement2 := createDefaultMeasurement("dns")
+	expectedMeasurement2.ID = measurementID2
+	getCall1 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement1, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID2).Return(expectedMeasurement2, nil).After(getCall1)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("dns")
+	ctx.History = view.NewHistoryBuffer(10)
+
+	now := defaultCurrentTime
+	viewerMock := mocks.NewMockViewer(ctrl)
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().DoAndReturn(func() time.Time { return now }).AnyTimes()
+	root := NewRoot(printer, ctx, viewerMock, timeMock, gbMock, nil)
+
+	// The second measurement starts one interval after the first one,
+	// a third one would start after the duration so the summary is output instead
+	outputCall1 := viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement1).DoAndReturn(func(_ context.Context, m *globalping.Measurement) error {
+		now = now.Add(time.Minute)
+		return nil
+	})
+	viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement2).DoAndReturn(func(_ context.Context, m *globalping.Measurement) error {
+		now = now.Add(30 * time.Second)
+		return nil
+	}).After(outputCall1)
+	viewerMock.EXPECT().OutputSummary().Times(1)
+
+	os.Args = []string{"globalping", "dns", "jsdelivr.com", "--infinite", "--interval", "1m", "--duration", "2m", "from", "Berlin"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "", w.String())
+	assert.Equal(t, 2, ctx.MeasurementsCreated)
+}
+
+func Test_Execute_DNS_Infinite_Flags_Require_Infinite(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("dns")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "dns", "jsdelivr.com", "--count", "5"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the --interval, --count and --duration flags require --infinite")
+}
+
+func Test_Execute_DNS_Assertions_Infinite(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("dns")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "dns", "jsdelivr.com", "--infinite", "--expect-status", "NOERROR"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the --expect-status and --expect-dns-answer flags are not supported with --infinite")
+}
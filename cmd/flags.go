@@ -0,0 +1,28 @@
+package cmd
+
+import "strconv"
+
+// A float flag which is nil unless it is set
+type optionalFloatValue struct {
+	p **float64
+}
+
+func (f optionalFloatValue) String() string {
+	if *f.p == nil {
+		return ""
+	}
+	return strconv.FormatFloat(**f.p, 'f', -1, 64)
+}
+
+func (f optionalFloatValue) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*f.p = &v
+	return nil
+}
+
+func (f optionalFloatValue) Type() string {
+	return "float"
+}
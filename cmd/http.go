@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/jsdelivr/globalping-cli/globalping"
-	"github.com/jsdelivr/globalping-cli/view"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -63,6 +62,15 @@ Examples:
   # HTTP HEAD request to example.com from a probe that is located in Berlin, specifying a different host example.org in the request headers
   http example.com from Berlin --host example.org
 
+  # HTTPS HEAD request to jsdelivr.com from 3 probes in Europe and compare their TLS certificates
+  http jsdelivr.com from Europe --limit 3 --tls
+
+  # HTTP HEAD request to jsdelivr.com from 3 probes and fail if any response isn't a 200 or takes more than 500ms
+  http jsdelivr.com --limit 3 --expect-status 200 --max-http-total 500ms --ci
+
+  # HTTP HEAD request to jsdelivr.com from 3 probes continuously and track the status changes
+  http jsdelivr.com --limit 3 --infinite
+
   # HTTP GET request google.com from a probe in ASN 123 with a dns resolver 1.1.1.1 and json output
   http google.com from 123 --resolver 1.1.1.1 --json`,
 	}
@@ -78,6 +86,11 @@ Examples:
 	flags.StringVar(&r.ctx.Method, "method", r.ctx.Method, "Specifies the HTTP method to use (HEAD or GET) (default \"HEAD\")")
 	flags.StringArrayVarP(&r.ctx.Headers, "header", "H", r.ctx.Headers, "Specifies a HTTP header to be added to the request, in the format \"Key: Value\". Multiple headers can be added by adding multiple flags")
 	flags.BoolVar(&r.ctx.Full, "full", r.ctx.Full, "Full output. Uses an HTTP GET request, and outputs the status, headers and body to the output")
+	flags.StringVar(&r.ctx.Assertions.ExpectStatus, "expect-status", r.ctx.Assertions.ExpectStatus, "Fail if the response status code of any probe differs from the given one, e.g. 200")
+	flags.DurationVar(&r.ctx.Assertions.MaxHTTPTotal, "max-http-total", r.ctx.Assertions.MaxHTTPTotal, "Fail if the total request time of any probe exceeds the given duration, e.g. 500ms")
+	flags.BoolVar(&r.ctx.Infinite, "infinite", r.ctx.Infinite, "Keep requesting the target with the same probes until stopped, showing the response times and status changes of every probe (default false)")
+	flags.BoolVar(&r.ctx.TLS, "tls", r.ctx.TLS, "Output the TLS certificate details and flag the certificates expiring soon or differing across probes. Requires the HTTPS or HTTP2 protocol, uses HTTPS if no protocol is set (default false)")
+	r.addInfiniteFlags(httpCmd)
 
 	r.Cmd.AddCommand(httpCmd)
 }
@@ -88,6 +101,11 @@ func (r *Root) RunHTTP(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	err = r.validateInfinite("--expect-status and --max-http-total")
+	if err != nil {
+		return err
+	}
+
 	defer r.UpdateHistory()
 	r.ctx.RecordToSession = true
 
@@ -102,31 +120,23 @@ func (r *Root) RunHTTP(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	res, showHelp, err := r.client.CreateMeasurement(opts)
+	ctx, cancel := r.contextWithCancel(cmd.Context())
+	defer cancel()
+
+	if r.ctx.Infinite {
+		return r.runInfinite(ctx, opts)
+	}
+
+	hm, err := r.createMeasurement(ctx, opts)
 	if err != nil {
-		if !showHelp {
-			cmd.SilenceUsage = true
-		}
 		return err
 	}
 
-	r.ctx.MeasurementsCreated++
-	hm := &view.HistoryItem{
-		Id:        res.ID,
-		Status:    globalping.StatusInProgress,
-		StartedAt: r.time.Now(),
-	}
-	r.ctx.History.Push(hm)
-	if r.ctx.RecordToSession {
-		r.ctx.RecordToSession = false
-		err := saveIdToSession(res.ID)
-		if err != nil {
-			r.printer.Printf("Warning: %s\n", err)
-		}
+	err = r.viewer.Output(ctx, hm.Id, opts)
+	if err != nil {
+		cmd.SilenceUsage = true
 	}
-
-	r.viewer.Output(res.ID, opts)
-	return nil
+	return err
 }
 
 const PostMeasurementTypeHttp = "http"
@@ -151,9 +161,17 @@ func (r *Root) buildHttpMeasurementRequest() (*globalping.MeasurementCreate, err
 		// override method to GET
 		method = "GET"
 	}
+	protocol := overrideOpt(urlData.Protocol, r.ctx.Protocol)
+	if r.ctx.TLS && strings.EqualFold(protocol, "http") {
+		// TLS details are only available over HTTPS, only the default protocol is upgraded
+		if r.ctx.Protocol != "" || strings.HasPrefix(strings.ToLower(r.ctx.Target), "http://") {
+			return nil, errors.New("the --tls flag requires the HTTPS or HTTP2 protocol")
+		}
+		protocol = "https"
+	}
 	opts.Target = urlData.Host
 	opts.Options = &globalping.MeasurementOptions{
-		Protocol: overrideOpt(urlData.Protocol, r.ctx.Protocol),
+		Protocol: protocol,
 		Port:     overrideOptInt(urlData.Port, r.ctx.Port),
 		Request: &globalping.RequestOptions{
 			Path:    overrideOpt(urlData.Path, r.ctx.Path),
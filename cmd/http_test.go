@@ -34,10 +34,10 @@ func Test_Execute_HTTP_Default(t *testing.T) {
 	expectedResponse := createDefaultMeasurementCreateResponse()
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().CreateMeasurement(expectedOpts).Times(1).Return(expectedResponse, false, nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Times(1).Return(expectedResponse, nil)
 
 	viewerMock := mocks.NewMockViewer(ctrl)
-	viewerMock.EXPECT().Output(measurementID1, expectedOpts).Times(1).Return(nil)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).Times(1).Return(nil)
 
 	timeMock := mocks.NewMockTime(ctrl)
 	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
@@ -224,3 +224,41 @@ func Test_BuildHttpMeasurementRequest_HEAD(t *testing.T) {
 
 	assert.Equal(t, expectedM, m)
 }
+
+func Test_BuildHttpMeasurementRequest_TLS(t *testing.T) {
+	ctx := createDefaultContext("http")
+	printer := view.NewPrinter(nil, nil, nil)
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+
+	ctx.Target = "example.com"
+	ctx.TLS = true
+
+	m, err := root.buildHttpMeasurementRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, "https", m.Options.Protocol)
+
+	ctx.Protocol = "HTTP2"
+
+	m, err = root.buildHttpMeasurementRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, "HTTP2", m.Options.Protocol)
+
+	// An explicit HTTP protocol is not upgraded
+	ctx.Protocol = "HTTP"
+	_, err = root.buildHttpMeasurementRequest()
+	assert.EqualError(t, err, "the --tls flag requires the HTTPS or HTTP2 protocol")
+
+	ctx.Protocol = ""
+	ctx.Target = "http://example.com"
+	_, err = root.buildHttpMeasurementRequest()
+	assert.EqualError(t, err, "the --tls flag requires the HTTPS or HTTP2 protocol")
+
+	ctx.Target = "HTTP://example.com"
+	_, err = root.buildHttpMeasurementRequest()
+	assert.EqualError(t, err, "the --tls flag requires the HTTPS or HTTP2 protocol")
+
+	ctx.Target = "https://example.com"
+	m, err = root.buildHttpMeasurementRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, "https", m.Options.Protocol)
+}
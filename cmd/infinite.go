@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/spf13/cobra"
+)
+
+// Adds the flags controlling how long a continuous measurement runs
+func (r *Root) addInfiniteFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.DurationVar(&r.ctx.Interval, "interval", r.ctx.Interval, "Minimum time between the start of two measurements in continuous mode, e.g. 1m. Requires --infinite")
+	flags.IntVar(&r.ctx.Count, "count", r.ctx.Count, "Stop after the given number of measurements and output the summary. Requires --infinite (default unlimited)")
+	flags.DurationVar(&r.ctx.Duration, "duration", r.ctx.Duration, "Stop starting new measurements after the given time, e.g. 30m, and output the summary once the last one finishes. Requires --infinite (default unlimited)")
+}
+
+// Rejects the flags which are not supported in continuous mode, assertionFlags are the assertion flags of the command
+func (r *Root) validateInfinite(assertionFlags string) error {
+	if !r.ctx.Infinite {
+		if r.ctx.Interval != 0 || r.ctx.Count != 0 || r.ctx.Duration != 0 {
+			return fmt.Errorf("the --interval, --count and --duration flags require --infinite")
+		}
+		return nil
+	}
+	if r.ctx.Interval < 0 || r.ctx.Count < 0 || r.ctx.Duration < 0 {
+		return fmt.Errorf("the --interval, --count and --duration flags must not be negative")
+	}
+	if r.ctx.Assertions.IsSet() {
+		return fmt.Errorf("the %s flags are not supported with --infinite", assertionFlags)
+	}
+	if r.ctx.JUnitFile != "" {
+		return fmt.Errorf("the --junit flag is not supported with --infinite")
+	}
+	return nil
+}
+
+// Repeats the measurement from the same probes until interrupted or the --count or --duration limit is reached, then outputs the summary
+func (r *Root) runInfinite(ctx context.Context, opts *globalping.MeasurementCreate) error {
+	if r.ctx.Limit > 5 {
+		return fmt.Errorf("continous mode is currently limited to 5 probes")
+	}
+	return r.endInfinite(r.repeatMeasurement(ctx, opts))
+}
+
+// Outputs the summary of a continuous measurement stopped by an interruption, the --count or --duration limit or the timeout
+func (r *Root) endInfinite(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) {
+		r.viewer.OutputSummary()
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, view.ErrTimeout) {
+		r.viewer.OutputSummary()
+		r.Cmd.SilenceUsage = true
+		return view.ErrTimeout
+	}
+	return err
+}
+
+// Creates a new measurement once the previous one is finished, using the probes of the previous one
+func (r *Root) repeatMeasurement(ctx context.Context, opts *globalping.MeasurementCreate) error {
+	startedAt := r.time.Now()
+	for {
+		wait := r.nextRoundIn()
+		if !r.canStartRound(startedAt, wait) {
+			return nil
+		}
+		err := utils.Sleep(ctx, wait)
+		if err != nil {
+			return err
+		}
+		last := r.ctx.History.Last()
+		if last != nil {
+			opts.Locations = []globalping.Locations{{Magic: last.Id}}
+		}
+		hm, err := r.createMeasurement(ctx, opts)
+		if err != nil {
+			return err
+		}
+		m, err := r.client.GetMeasurement(ctx, hm.Id)
+		for err == nil && m.Status == globalping.StatusInProgress {
+			err = utils.Sleep(ctx, r.ctx.APIMinInterval)
+			if err == nil {
+				m, err = r.client.GetMeasurement(ctx, hm.Id)
+			}
+		}
+		if err != nil {
+			r.Cmd.SilenceUsage = true
+			return err
+		}
+		hm.Status = m.Status
+		err = r.viewer.OutputInfinite(ctx, m)
+		if err != nil {
+			r.Cmd.SilenceUsage = true
+			return err
+		}
+		err = utils.Sleep(ctx, r.ctx.APIMinInterval)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Returns whether the continuous measurement started at startedAt can start another measurement after waiting for wait
+func (r *Root) canStartRound(startedAt time.Time, wait time.Duration) bool {
+	if r.ctx.Count > 0 && r.ctx.MeasurementsCreated >= r.ctx.Count {
+		return false
+	}
+	if r.ctx.Duration > 0 && r.time.Now().Add(wait).Sub(startedAt) >= r.ctx.Duration {
+		return false
+	}
+	return true
+}
+
+// Returns the time left before the next measurement can be started according to the --interval flag
+func (r *Root) nextRoundIn() time.Duration {
+	last := r.ctx.History.Last()
+	if r.ctx.Interval == 0 || last == nil {
+		return 0
+	}
+	return max(r.ctx.Interval-r.time.Now().Sub(last.StartedAt), 0)
+}
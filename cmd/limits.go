@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/spf13/cobra"
+)
+
+func (r *Root) initLimits() {
+	limitsCmd := &cobra.Command{
+		RunE:  r.RunLimits,
+		Use:   "limits",
+		Short: "Show the current rate limits and remaining credits",
+		Long: `Show the number of measurements you can still create, when the limit resets and whether the requests are authenticated.
+
+Examples:
+  # Show the current limits
+  limits
+
+  # Show the current limits in JSON format
+  limits --json`,
+	}
+
+	r.Cmd.AddCommand(limitsCmd)
+}
+
+func (r *Root) RunLimits(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	ctx, cancel := r.contextWithCancel(cmd.Context())
+	defer cancel()
+
+	limits, err := r.client.GetLimits(ctx)
+	if err != nil {
+		return err
+	}
+
+	if r.ctx.ToJSON {
+		b, err := json.MarshalIndent(limits, "", "  ")
+		if err != nil {
+			return err
+		}
+		r.printer.Println(string(b))
+		return nil
+	}
+
+	create := limits.RateLimits.Measurements.Create
+	authenticated := "no"
+	if create.Type == globalping.CreateLimitTypeUser {
+		authenticated = "yes"
+	}
+	reset := "-"
+	if create.Reset > 0 {
+		reset = (time.Duration(create.Reset) * time.Second).String()
+	}
+	r.printer.Printf("Authenticated: %s\n", authenticated)
+	r.printer.Printf("Measurements limit: %d (%d remaining)\n", create.Limit, create.Remaining)
+	r.printer.Printf("Resets in: %s\n", reset)
+	if limits.Credits != nil {
+		r.printer.Printf("Remaining credits: %d\n", limits.Credits.Remaining)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Execute_Limits_Default(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetLimits(gomock.Any()).Times(1).Return(&globalping.LimitsResponse{
+		RateLimits: globalping.RateLimits{
+			Measurements: globalping.MeasurementsLimits{
+				Create: globalping.MeasurementsCreateLimits{
+					Type:      globalping.CreateLimitTypeUser,
+					Limit:     500,
+					Remaining: 350,
+					Reset:     600,
+				},
+			},
+		},
+		Credits: &globalping.CreditLimits{
+			Remaining: 1000,
+		},
+	}, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("limits")
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "limits"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Authenticated: yes
+Measurements limit: 500 (350 remaining)
+Resets in: 10m0s
+Remaining credits: 1000
+`, w.String())
+}
+
+func Test_Execute_Limits_Json(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetLimits(gomock.Any()).Times(1).Return(&globalping.LimitsResponse{
+		RateLimits: globalping.RateLimits{
+			Measurements: globalping.MeasurementsLimits{
+				Create: globalping.MeasurementsCreateLimits{
+					Type:      globalping.CreateLimitTypeIP,
+					Limit:     250,
+					Remaining: 250,
+				},
+			},
+		},
+	}, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("limits")
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "limits", "--json"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `{
+  "rateLimit": {
+    "measurements": {
+      "create": {
+        "type": "ip",
+        "limit": 250,
+        "remaining": 250,
+        "reset": 0
+      }
+    }
+  }
+}
+`, w.String())
+}
+
+func Test_Execute_Limits_Format_Json(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetLimits(gomock.Any()).Times(1).Return(&globalping.LimitsResponse{}, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("limits")
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "limits", "--format", "json"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.True(t, ctx.ToJSON)
+	assert.Contains(t, w.String(), `"rateLimit"`)
+}
@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/jsdelivr/globalping-cli/globalping"
-	"github.com/jsdelivr/globalping-cli/view"
 	"github.com/spf13/cobra"
 )
 
@@ -38,6 +35,18 @@ Examples:
   # MTR jsdelivr.com from a probe that is from the AWS network and is located in Montreal using the TCP protocol and port 453
   mtr jsdelivr.com from aws+montreal --protocol tcp --port 453
 
+  # MTR jsdelivr.com from 2 probes in Europe with the hops as aligned tables
+  mtr jsdelivr.com from Europe --limit 2 --table
+
+  # MTR jsdelivr.com from 2 probes in Europe continuously, merging the stats of every hop
+  mtr jsdelivr.com from Europe --limit 2 --infinite
+
+  # MTR jsdelivr.com from a probe in Germany with latency output
+  mtr jsdelivr.com from Germany --latency
+
+  # MTR jsdelivr.com from 2 probes in Germany and fail if the loss to the target exceeds 5%
+  mtr jsdelivr.com from Germany --limit 2 --max-loss 5
+
   # MTR jsdelivr.com from a probe in ASN 123 with json output
   mtr jsdelivr.com from 123 --json`,
 	}
@@ -47,6 +56,10 @@ Examples:
 	flags.StringVar(&r.ctx.Protocol, "protocol", r.ctx.Protocol, "Specifies the protocol used (ICMP, TCP or UDP) (default \"icmp\")")
 	flags.IntVar(&r.ctx.Port, "port", r.ctx.Port, "Specifies the port to use. Only applicable for TCP protocol (default 53)")
 	flags.IntVar(&r.ctx.Packets, "packets", r.ctx.Packets, "Specifies the number of packets to send to each hop (default 3)")
+	flags.BoolVar(&r.ctx.Infinite, "infinite", r.ctx.Infinite, "Keep tracing the route with the same probes until stopped, merging the stats of every hop across the measurements (default false)")
+	flags.Var(optionalFloatValue{&r.ctx.Assertions.MaxLoss}, "max-loss", "Fail if the packet loss to the target of any probe exceeds the given percentage")
+	flags.DurationVar(&r.ctx.Assertions.MaxAvgRTT, "max-avg-rtt", r.ctx.Assertions.MaxAvgRTT, "Fail if the average RTT to the target of any probe exceeds the given duration, e.g. 80ms")
+	r.addInfiniteFlags(mtrCmd)
 
 	r.Cmd.AddCommand(mtrCmd)
 }
@@ -57,8 +70,9 @@ func (r *Root) RunMTR(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if r.ctx.ToLatency {
-		return fmt.Errorf("the latency flag is not supported by the mtr command")
+	err = r.validateInfinite("--max-loss and --max-avg-rtt")
+	if err != nil {
+		return err
 	}
 
 	defer r.UpdateHistory()
@@ -81,29 +95,21 @@ func (r *Root) RunMTR(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	res, showHelp, err := r.client.CreateMeasurement(opts)
+	ctx, cancel := r.contextWithCancel(cmd.Context())
+	defer cancel()
+
+	if r.ctx.Infinite {
+		return r.runInfinite(ctx, opts)
+	}
+
+	hm, err := r.createMeasurement(ctx, opts)
 	if err != nil {
-		if !showHelp {
-			cmd.SilenceUsage = true
-		}
 		return err
 	}
 
-	r.ctx.MeasurementsCreated++
-	hm := &view.HistoryItem{
-		Id:        res.ID,
-		Status:    globalping.StatusInProgress,
-		StartedAt: r.time.Now(),
-	}
-	r.ctx.History.Push(hm)
-	if r.ctx.RecordToSession {
-		r.ctx.RecordToSession = false
-		err := saveIdToSession(res.ID)
-		if err != nil {
-			r.printer.Printf("Warning: %s\n", err)
-		}
+	err = r.viewer.Output(ctx, hm.Id, opts)
+	if err != nil {
+		cmd.SilenceUsage = true
 	}
-
-	r.viewer.Output(res.ID, opts)
-	return nil
+	return err
 }
@@ -27,10 +27,10 @@ func Test_Execute_MTR_Default(t *testing.T) {
 	expectedResponse := createDefaultMeasurementCreateResponse()
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().CreateMeasurement(expectedOpts).Times(1).Return(expectedResponse, false, nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Times(1).Return(expectedResponse, nil)
 
 	viewerMock := mocks.NewMockViewer(ctrl)
-	viewerMock.EXPECT().Output(measurementID1, expectedOpts).Times(1).Return(nil)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).Times(1).Return(nil)
 
 	timeMock := mocks.NewMockTime(ctrl)
 	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
@@ -73,3 +73,15 @@ func Test_Execute_MTR_Default(t *testing.T) {
 	)
 	assert.Equal(t, expectedHistory, string(b))
 }
+
+func Test_Execute_MTR_Assertions_Infinite(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("mtr")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "mtr", "jsdelivr.com", "--infinite", "--max-loss", "5"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the --max-loss and --max-avg-rtt flags are not supported with --infinite")
+}
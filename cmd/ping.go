@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"syscall"
+	"net"
+	"net/http"
 	"time"
 
 	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/utils"
 	"github.com/jsdelivr/globalping-cli/view"
 	"github.com/spf13/cobra"
 )
@@ -43,14 +47,32 @@ Examples:
   # Ping jsdelivr.com from a probe in ASN 123 with json output
   ping jsdelivr.com from 123 --json
 
+  # Ping google.com from 3 probes in Europe and fail if any of them loses packets or has an average RTT above 80ms
+  ping google.com from Europe --limit 3 --max-loss 0 --max-avg-rtt 80ms --ci
+
   # Continuously ping google.com from New York
-  ping google.com from New York --infinite`,
+  ping google.com from New York --infinite
+
+  # Ping google.com from New York for 30 minutes, one round of 10 packets per minute, then output the summary
+  ping google.com from New York --infinite --interval 1m --duration 30m --packets-per-round 10
+
+  # Continuously ping google.com from 3 probes and expose the stats to Prometheus on port 9100
+  ping google.com --limit 3 --infinite --prometheus-listen :9100
+
+  # Continuously ping google.com and push the stats to a Pushgateway
+  ping google.com --infinite --prometheus-push http://localhost:9091`,
 	}
 
 	// ping specific flags
 	flags := pingCmd.Flags()
 	flags.IntVar(&r.ctx.Packets, "packets", r.ctx.Packets, "Specifies the desired amount of ECHO_REQUEST packets to be sent (default 3)")
 	flags.BoolVar(&r.ctx.Infinite, "infinite", r.ctx.Infinite, "Keep pinging the target continuously until stopped (default false)")
+	flags.Var(optionalFloatValue{&r.ctx.Assertions.MaxLoss}, "max-loss", "Fail if the packet loss of any probe exceeds the given percentage")
+	flags.DurationVar(&r.ctx.Assertions.MaxAvgRTT, "max-avg-rtt", r.ctx.Assertions.MaxAvgRTT, "Fail if the average RTT of any probe exceeds the given duration, e.g. 80ms")
+	flags.StringVar(&r.ctx.PrometheusListen, "prometheus-listen", r.ctx.PrometheusListen, "Serve the per-probe stats in the Prometheus format on the given address, e.g. :9100. Requires --infinite")
+	flags.StringVar(&r.ctx.PrometheusPush, "prometheus-push", r.ctx.PrometheusPush, "Periodically push the per-probe stats to the given Pushgateway URL. Requires --infinite")
+	flags.IntVar(&r.ctx.PacketsPerRound, "packets-per-round", r.ctx.PacketsPerRound, "Specifies the amount of packets sent by every measurement in continuous mode, up to 16. Requires --infinite (default 16)")
+	r.addInfiniteFlags(pingCmd)
 
 	r.Cmd.AddCommand(pingCmd)
 }
@@ -61,10 +83,32 @@ func (r *Root) RunPing(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	format := view.SelectedFormat(r.ctx)
+	if (r.ctx.PrometheusListen != "" || r.ctx.PrometheusPush != "") && !r.ctx.Infinite {
+		return fmt.Errorf("the --prometheus-listen and --prometheus-push flags require --infinite")
+	}
+	// The metrics are updated from the stats of the default and latency outputs
+	if (r.ctx.PrometheusListen != "" || r.ctx.PrometheusPush != "") && format != "" && format != view.FormatLatency {
+		return fmt.Errorf("the --prometheus-listen and --prometheus-push flags can't be used with the %s format", format)
+	}
+	if r.ctx.PacketsPerRound != 0 && !r.ctx.Infinite {
+		return fmt.Errorf("the --packets-per-round flag requires --infinite")
+	}
+	if r.ctx.PacketsPerRound < 0 || r.ctx.PacketsPerRound > 16 {
+		return fmt.Errorf("the --packets-per-round flag must be between 1 and 16")
+	}
+	err = r.validateInfinite("--max-loss and --max-avg-rtt")
+	if err != nil {
+		return err
+	}
+
 	defer r.UpdateHistory()
 	r.ctx.RecordToSession = true
 	if r.ctx.Infinite {
-		r.ctx.Packets = 16
+		r.ctx.Packets = r.ctx.PacketsPerRound
+		if r.ctx.Packets == 0 {
+			r.ctx.Packets = 16
+		}
 	}
 
 	opts := &globalping.MeasurementCreate{
@@ -82,47 +126,110 @@ func (r *Root) RunPing(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	ctx, cancel := r.contextWithCancel(cmd.Context())
+	defer cancel()
+
 	if r.ctx.Infinite {
-		return r.pingInfinite(opts)
+		return r.pingInfinite(ctx, opts)
 	}
 
-	hm, err := r.createMeasurement(opts)
+	hm, err := r.createMeasurement(ctx, opts)
 	if err != nil {
 		return err
 	}
-	return r.viewer.Output(hm.Id, opts)
+	err = r.viewer.Output(ctx, hm.Id, opts)
+	if err != nil {
+		cmd.SilenceUsage = true
+	}
+	return err
 }
 
-func (r *Root) pingInfinite(opts *globalping.MeasurementCreate) error {
+func (r *Root) pingInfinite(ctx context.Context, opts *globalping.MeasurementCreate) error {
 	if r.ctx.Limit > 5 {
 		return fmt.Errorf("continous mode is currently limited to 5 probes")
 	}
 
-	var err error
-	go func() {
-		err = r.ping(opts)
+	if r.ctx.PrometheusListen != "" || r.ctx.PrometheusPush != "" {
+		stop, err := r.startMetrics(ctx)
+		if err != nil {
+			r.Cmd.SilenceUsage = true
+			return err
+		}
+		defer stop()
+	}
+
+	// Runs until interrupted, the --count or --duration limit or the timeout is reached, in which case the summary is printed
+	return r.endInfinite(r.ping(ctx, opts))
+}
+
+// Time between two pushes of the metrics to the Pushgateway
+var metricsPushInterval = 15 * time.Second
+
+// Starts serving and pushing the metrics of the continuous measurement.
+// The returned function stops the server and pushes the final metrics.
+func (r *Root) startMetrics(ctx context.Context) (func(), error) {
+	metrics := view.NewMetrics()
+	r.ctx.Metrics = metrics
+
+	var server *http.Server
+	if r.ctx.PrometheusListen != "" {
+		l, err := net.Listen("tcp", r.ctx.PrometheusListen)
 		if err != nil {
-			r.cancel <- syscall.SIGINT
+			return nil, fmt.Errorf("failed to serve metrics: %w", err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics)
+		server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go server.Serve(l)
+	}
+
+	pushCtx, cancelPush := context.WithCancel(ctx)
+	pushDone := make(chan struct{})
+	go func() {
+		defer close(pushDone)
+		if r.ctx.PrometheusPush == "" {
 			return
 		}
+		ticker := time.NewTicker(metricsPushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-pushCtx.Done():
+				return
+			case <-ticker.C:
+				err := metrics.Push(pushCtx, r.ctx.PrometheusPush)
+				if err != nil && pushCtx.Err() == nil {
+					r.printer.ErrPrintf("Warning: %s\n", err)
+				}
+			}
+		}
 	}()
-	<-r.cancel
 
-	if err == nil {
-		r.viewer.OutputSummary()
-	}
-	return err
+	return func() {
+		cancelPush()
+		<-pushDone
+		if r.ctx.PrometheusPush != "" {
+			err := metrics.Push(context.Background(), r.ctx.PrometheusPush)
+			if err != nil {
+				r.printer.ErrPrintf("Warning: %s\n", err)
+			}
+		}
+		if server != nil {
+			server.Close()
+		}
+	}, nil
 }
 
-func (r *Root) ping(opts *globalping.MeasurementCreate) error {
+func (r *Root) ping(ctx context.Context, opts *globalping.MeasurementCreate) error {
 	var runErr error
+	startedAt := r.time.Now()
 	mbuf := NewMeasurementsBuffer(10) // 10 is the maximum number of measurements that can be in progress at the same time
 	for {
 		mbuf.Restart()
 		elapsedTime := time.Duration(0)
 		el := mbuf.Next()
 		for el != nil {
-			m, err := r.client.GetMeasurement(el.Id)
+			m, err := r.client.GetMeasurement(ctx, el.Id)
 			if err != nil {
 				r.Cmd.SilenceUsage = true
 				return err
@@ -132,11 +239,14 @@ func (r *Root) ping(opts *globalping.MeasurementCreate) error {
 				el = mbuf.Next()
 				continue
 			}
-			err = r.viewer.OutputInfinite(m)
+			err = r.viewer.OutputInfinite(ctx, m)
 			if err != nil {
 				r.Cmd.SilenceUsage = true
 				return err
 			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			if m.Status != globalping.StatusInProgress {
 				mbuf.Remove(el)
 			} else {
@@ -145,11 +255,14 @@ func (r *Root) ping(opts *globalping.MeasurementCreate) error {
 					el.ProbeStatus[i] = m.Results[i].Result.Status
 				}
 			}
-			if runErr == nil && mbuf.CanAppend() {
+			if runErr == nil && mbuf.CanAppend() && r.nextRoundIn() == 0 && r.canStartRound(startedAt, 0) {
 				opts.Locations = []globalping.Locations{{Magic: r.ctx.History.Last().Id}}
 				start := r.time.Now()
-				hm, err := r.createMeasurement(opts)
+				hm, err := r.createMeasurement(ctx, opts)
 				if err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
 					runErr = err // Return the error after all measurements have finished
 				}
 				mbuf.Append(hm)
@@ -158,17 +271,28 @@ func (r *Root) ping(opts *globalping.MeasurementCreate) error {
 			el = mbuf.Next()
 		}
 		if mbuf.Len() > 0 {
-			time.Sleep(r.ctx.APIMinInterval - elapsedTime)
+			err := utils.Sleep(ctx, r.ctx.APIMinInterval-elapsedTime)
+			if err != nil {
+				return err
+			}
 			continue
 		}
 		if runErr != nil {
 			return runErr
 		}
+		wait := r.nextRoundIn()
+		if !r.canStartRound(startedAt, wait) {
+			return nil
+		}
+		err := utils.Sleep(ctx, wait)
+		if err != nil {
+			return err
+		}
 		last := r.ctx.History.Last()
 		if last != nil {
 			opts.Locations = []globalping.Locations{{Magic: r.ctx.History.Last().Id}}
 		}
-		hm, err := r.createMeasurement(opts)
+		hm, err := r.createMeasurement(ctx, opts)
 		if err != nil {
 			return err
 		}
@@ -176,12 +300,31 @@ func (r *Root) ping(opts *globalping.MeasurementCreate) error {
 	}
 }
 
-func (r *Root) createMeasurement(opts *globalping.MeasurementCreate) (*view.HistoryItem, error) {
-	res, showHelp, err := r.client.CreateMeasurement(opts)
+func (r *Root) createMeasurement(ctx context.Context, opts *globalping.MeasurementCreate) (*view.HistoryItem, error) {
+	res, err := r.client.CreateMeasurement(ctx, opts)
+	for err != nil && r.ctx.WaitOnLimit {
+		rateLimitErr := &globalping.RateLimitError{}
+		if !errors.As(err, &rateLimitErr) {
+			break
+		}
+		wait := rateLimitErr.Reset
+		if wait <= 0 {
+			wait = time.Second
+		}
+		r.printer.ErrPrintf("Rate limit exceeded, waiting %s for the limit to reset...\n", wait)
+		err = utils.Sleep(ctx, wait)
+		if err != nil {
+			break
+		}
+		res, err = r.client.CreateMeasurement(ctx, opts)
+	}
 	if err != nil {
-		if !showHelp {
+		if !isUsageError(err) {
 			r.Cmd.SilenceUsage = true
 		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, view.ErrTimeout
+		}
 		return nil, err
 	}
 	r.ctx.MeasurementsCreated++
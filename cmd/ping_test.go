@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -27,10 +31,10 @@ func Test_Execute_Ping_Default(t *testing.T) {
 	expectedResponse := createDefaultMeasurementCreateResponse()
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().CreateMeasurement(expectedOpts).Times(1).Return(expectedResponse, false, nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Times(1).Return(expectedResponse, nil)
 
 	viewerMock := mocks.NewMockViewer(ctrl)
-	viewerMock.EXPECT().Output(measurementID1, expectedOpts).Times(1).Return(nil)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).Times(1).Return(nil)
 
 	timeMock := mocks.NewMockTime(ctrl)
 	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
@@ -77,12 +81,12 @@ func Test_Execute_Ping_Locations_And_Session(t *testing.T) {
 
 	totalCalls := 10
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().CreateMeasurement(expectedOpts).Times(totalCalls).Return(expectedResponse, false, nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Times(totalCalls).Return(expectedResponse, nil)
 
 	viewerMock := mocks.NewMockViewer(ctrl)
-	c1 := viewerMock.EXPECT().Output(measurementID1, expectedOpts).Times(4).Return(nil)
-	c2 := viewerMock.EXPECT().Output(measurementID2, expectedOpts).Times(3).Return(nil).After(c1)
-	viewerMock.EXPECT().Output(measurementID3, expectedOpts).Times(3).Return(nil).After(c2)
+	c1 := viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).Times(4).Return(nil)
+	c2 := viewerMock.EXPECT().Output(gomock.Any(), measurementID2, expectedOpts).Times(3).Return(nil).After(c1)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID3, expectedOpts).Times(3).Return(nil).After(c2)
 
 	timeMock := mocks.NewMockTime(ctrl)
 	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
@@ -294,10 +298,10 @@ func Test_Execute_Ping_Infinite(t *testing.T) {
 	expectedResponse4.ID = measurementID4
 
 	gbMock := mocks.NewMockClient(ctrl)
-	createCall1 := gbMock.EXPECT().CreateMeasurement(expectedOpts1).Return(expectedResponse1, false, nil)
-	createCall2 := gbMock.EXPECT().CreateMeasurement(expectedOpts2).Return(expectedResponse2, false, nil).After(createCall1)
-	createCall3 := gbMock.EXPECT().CreateMeasurement(expectedOpts3).Return(expectedResponse3, false, nil).After(createCall2)
-	gbMock.EXPECT().CreateMeasurement(expectedOpts4).Return(expectedResponse4, false, nil).After(createCall3)
+	createCall1 := gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts1).Return(expectedResponse1, nil)
+	createCall2 := gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts2).Return(expectedResponse2, nil).After(createCall1)
+	createCall3 := gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts3).Return(expectedResponse3, nil).After(createCall2)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts4).Return(expectedResponse4, nil).After(createCall3)
 
 	expectedMeasurement1 := createDefaultMeasurement_MultipleProbes("ping", globalping.StatusFinished)
 	expectedMeasurement2 := createDefaultMeasurement_MultipleProbes("ping", globalping.StatusInProgress)
@@ -310,23 +314,23 @@ func Test_Execute_Ping_Infinite(t *testing.T) {
 	expectedMeasurement4.ID = measurementID4
 	expectedMeasurement4.Results[1].Result.Status = globalping.StatusFinished
 
-	getCall1 := gbMock.EXPECT().GetMeasurement(measurementID1).Return(expectedMeasurement1, nil)
-	getCall2 := gbMock.EXPECT().GetMeasurement(measurementID2).Return(expectedMeasurement2, nil).After(getCall1)
-	getCall3 := gbMock.EXPECT().GetMeasurement(measurementID3).Return(expectedMeasurement3, nil).After(getCall2)
-	getCall4 := gbMock.EXPECT().GetMeasurement(measurementID4).Return(expectedMeasurement4, nil).After(getCall3)
-	getCall5 := gbMock.EXPECT().GetMeasurement(measurementID2).Return(expectedMeasurement2, nil).After(getCall4)
-	getCall6 := gbMock.EXPECT().GetMeasurement(measurementID3).Return(expectedMeasurement3, nil).After(getCall5)
-	gbMock.EXPECT().GetMeasurement(measurementID4).Return(expectedMeasurement4, nil).After(getCall6)
+	getCall1 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement1, nil)
+	getCall2 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID2).Return(expectedMeasurement2, nil).After(getCall1)
+	getCall3 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID3).Return(expectedMeasurement3, nil).After(getCall2)
+	getCall4 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID4).Return(expectedMeasurement4, nil).After(getCall3)
+	getCall5 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID2).Return(expectedMeasurement2, nil).After(getCall4)
+	getCall6 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID3).Return(expectedMeasurement3, nil).After(getCall5)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID4).Return(expectedMeasurement4, nil).After(getCall6)
 
 	viewerMock := mocks.NewMockViewer(ctrl)
-	waitFn := func(m *globalping.Measurement) error { time.Sleep(5 * time.Millisecond); return nil }
-	outputCall1 := viewerMock.EXPECT().OutputInfinite(expectedMeasurement1).DoAndReturn(waitFn)
-	outputCall2 := viewerMock.EXPECT().OutputInfinite(expectedMeasurement2).DoAndReturn(waitFn).After(outputCall1)
-	outputCall3 := viewerMock.EXPECT().OutputInfinite(expectedMeasurement3).DoAndReturn(waitFn).After(outputCall2)
-	outputCall4 := viewerMock.EXPECT().OutputInfinite(expectedMeasurement4).DoAndReturn(waitFn).After(outputCall3)
-	outputCall5 := viewerMock.EXPECT().OutputInfinite(expectedMeasurement2).DoAndReturn(waitFn).After(outputCall4)
-	outputCall6 := viewerMock.EXPECT().OutputInfinite(expectedMeasurement3).DoAndReturn(waitFn).After(outputCall5)
-	viewerMock.EXPECT().OutputInfinite(expectedMeasurement4).DoAndReturn(func(m *globalping.Measurement) error {
+	waitFn := func(_ context.Context, m *globalping.Measurement) error { time.Sleep(5 * time.Millisecond); return nil }
+	outputCall1 := viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement1).DoAndReturn(waitFn)
+	outputCall2 := viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement2).DoAndReturn(waitFn).After(outputCall1)
+	outputCall3 := viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement3).DoAndReturn(waitFn).After(outputCall2)
+	outputCall4 := viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement4).DoAndReturn(waitFn).After(outputCall3)
+	outputCall5 := viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement2).DoAndReturn(waitFn).After(outputCall4)
+	outputCall6 := viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement3).DoAndReturn(waitFn).After(outputCall5)
+	viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement4).DoAndReturn(func(_ context.Context, m *globalping.Measurement) error {
 		time.Sleep(500 * time.Millisecond)
 		return nil
 	}).After(outputCall6)
@@ -435,13 +439,13 @@ func Test_Execute_Ping_Infinite_Output_Error(t *testing.T) {
 	expectedResponse1 := createDefaultMeasurementCreateResponse()
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().CreateMeasurement(expectedOpts1).Return(expectedResponse1, false, nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts1).Return(expectedResponse1, nil)
 
 	expectedMeasurement := createDefaultMeasurement("ping")
-	gbMock.EXPECT().GetMeasurement(measurementID1).Return(expectedMeasurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement, nil)
 
 	viewerMock := mocks.NewMockViewer(ctrl)
-	viewerMock.EXPECT().OutputInfinite(expectedMeasurement).Return(errors.New("error message"))
+	viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement).Return(errors.New("error message"))
 	viewerMock.EXPECT().OutputSummary().Times(0)
 
 	timeMock := mocks.NewMockTime(ctrl)
@@ -477,3 +481,261 @@ func Test_Execute_Ping_Infinite_Output_Error(t *testing.T) {
 	)
 	assert.Equal(t, expectedHistory, string(b))
 }
+
+func Test_Execute_Ping_Infinite_Count(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts1 := createDefaultMeasurementCreate("ping")
+	expectedOpts1.Options.Packets = 8
+	expectedOpts2 := createDefaultMeasurementCreate("ping")
+	expectedOpts2.Options.Packets = 8
+	expectedOpts2.Locations[0].Magic = measurementID1
+
+	expectedResponse2 := createDefaultMeasurementCreateResponse()
+	expectedResponse2.ID = measurementID2
+
+	gbMock := mocks.NewMockClient(ctrl)
+	createCall1 := gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts1).Return(createDefaultMeasurementCreateResponse(), nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts2).Return(expectedResponse2, nil).After(createCall1)
+
+	expectedMeasurement1 := createDefaultMeasurement("ping")
+	expectedMeasurement2 := createDefaultMeasurement("ping")
+	expectedMeasurement2.ID = measurementID2
+	getCall1 := gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement1, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID2).Return(expectedMeasurement2, nil).After(getCall1)
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	outputCall1 := viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement1).Return(nil)
+	viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement2).Return(nil).After(outputCall1)
+	viewerMock.EXPECT().OutputSummary().Times(1)
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	ctx.History = view.NewHistoryBuffer(10)
+	root := NewRoot(printer, ctx, viewerMock, timeMock, gbMock, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--infinite", "--count", "2", "--packets-per-round", "8", "from", "Berlin"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", w.String())
+	assert.Equal(t, 2, ctx.MeasurementsCreated)
+}
+
+func Test_Execute_Ping_Packets_Per_Round_Invalid(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--packets-per-round", "8"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the --packets-per-round flag requires --infinite")
+
+	ctx = createDefaultContext("ping")
+	root = NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--infinite", "--packets-per-round", "20"}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the --packets-per-round flag must be between 1 and 16")
+}
+
+func Test_Execute_Ping_Wait_On_Limit(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedResponse := createDefaultMeasurementCreateResponse()
+	rateLimitErr := &globalping.RateLimitError{Limit: 100, Remaining: 0, Reset: 10 * time.Millisecond}
+
+	gbMock := mocks.NewMockClient(ctrl)
+	createCall := gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Times(2).Return(nil, rateLimitErr)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(expectedResponse, nil).After(createCall)
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).Times(1).Return(nil)
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	errW := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, errW)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, viewerMock, timeMock, gbMock, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Berlin", "--wait-on-limit"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", w.String())
+	assert.Equal(t, `Rate limit exceeded, waiting 10ms for the limit to reset...
+Rate limit exceeded, waiting 10ms for the limit to reset...
+`, errW.String())
+
+	expectedCtx := createDefaultExpectedContext("ping")
+	expectedCtx.WaitOnLimit = true
+	assert.Equal(t, expectedCtx, ctx)
+}
+
+func Test_Execute_Ping_Rate_Limit(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	rateLimitErr := &globalping.RateLimitError{Limit: 100, Remaining: 0, Reset: 10 * time.Minute}
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Times(1).Return(nil, rateLimitErr)
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, viewerMock, timeMock, gbMock, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Berlin"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.ErrorIs(t, err, rateLimitErr)
+
+	assert.Equal(t, "Error: rate limit exceeded - please try again in 10m0s or authenticate to get higher limits\n", w.String())
+}
+
+func Test_Execute_Ping_Prometheus_Requires_Infinite(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	viewerMock := mocks.NewMockViewer(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, viewerMock, nil, gbMock, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--prometheus-listen", ":9100"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the --prometheus-listen and --prometheus-push flags require --infinite")
+}
+
+func Test_Execute_Ping_Prometheus_Format(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	for _, args := range [][]string{{"--format", "csv"}, {"--format", "ndjson"}, {"--json"}} {
+		w := new(bytes.Buffer)
+		printer := view.NewPrinter(nil, w, w)
+		ctx := createDefaultContext("ping")
+		root := NewRoot(printer, ctx, nil, nil, nil, nil)
+		os.Args = append([]string{"globalping", "ping", "jsdelivr.com", "--infinite", "--prometheus-listen", ":9100"}, args...)
+		err := root.Cmd.ExecuteContext(context.TODO())
+		format := strings.TrimPrefix(args[len(args)-1], "--")
+		assert.EqualError(t, err, "the --prometheus-listen and --prometheus-push flags can't be used with the "+format+" format")
+	}
+}
+
+func Test_Execute_Ping_Infinite_Prometheus_Push(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pushedPaths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pushedPaths = append(pushedPaths, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Options.Packets = 16
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(createDefaultMeasurementCreateResponse(), nil)
+
+	expectedMeasurement := createDefaultMeasurement("ping")
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement, nil)
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().OutputInfinite(gomock.Any(), expectedMeasurement).Return(errors.New("error message"))
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, viewerMock, timeMock, gbMock, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--infinite", "--prometheus-push", server.URL, "from", "Berlin"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "error message")
+
+	assert.NotNil(t, ctx.Metrics)
+	assert.Equal(t, []string{"PUT /metrics/job/globalping"}, pushedPaths)
+}
+
+func Test_Execute_Ping_Assertions(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(createDefaultMeasurementCreateResponse(), nil)
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).Return(fmt.Errorf("%w: 1 of 2 checks failed", view.ErrAssertionFailed))
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, viewerMock, timeMock, gbMock, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Berlin", "--max-loss", "0", "--max-avg-rtt", "80ms"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "assertion failed: 1 of 2 checks failed")
+	assert.Equal(t, ExitCodeAssertion, exitCode(err))
+
+	assert.Equal(t, "Error: assertion failed: 1 of 2 checks failed\n", w.String())
+	assert.Equal(t, 0.0, *ctx.Assertions.MaxLoss)
+	assert.Equal(t, 80*time.Millisecond, ctx.Assertions.MaxAvgRTT)
+}
+
+func Test_Execute_Ping_Assertions_Infinite(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--infinite", "--max-loss", "5"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the --max-loss and --max-avg-rtt flags are not supported with --infinite")
+}
+
+func Test_Execute_Ping_JUnit_Infinite(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--infinite", "--junit", "report.xml"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the --junit flag is not supported with --infinite")
+}
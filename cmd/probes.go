@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/spf13/cobra"
+)
+
+func (r *Root) initProbes() {
+	probesCmd := &cobra.Command{
+		RunE:  r.RunProbes,
+		Use:   "probes [location]",
+		Short: "List the online probes matching a location",
+		Long: `List the online probes matching a location, to check which probes a --from value will select before running a measurement.
+The location accepts the same syntax as the --from flag of the measurement commands: a comma-separated list of continents, regions, countries, US states, cities, networks, ASNs or tags, combined with "+" to match all of them.
+The matching approximates the one of the API, so the probes selected by a measurement may differ slightly:
+continents, countries and US states are matched by their codes or names, regions by their full names,
+cities and networks by whole words of their names, and tags by their full names or prefixes, e.g. aws for aws-eu-central-1.
+Use a measurement ID or the previous measurement shortcuts to list the probes used by a previous measurement.
+
+Examples:
+  # List all online probes
+  probes
+
+  # List the probes in Germany or Poland
+  probes de,pl
+
+  # List the probes in the AWS network in Europe
+  probes --from europe+aws
+
+  # List the probes used by the last measurement
+  probes last
+
+  # List the probes in Germany in JSON format
+  probes de --json`,
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	r.Cmd.AddCommand(probesCmd)
+}
+
+func (r *Root) RunProbes(cmd *cobra.Command, args []string) error {
+	err := r.updateCIMode()
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		r.ctx.From = args[0]
+	}
+	locations, err := r.getLocations()
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancel := r.contextWithCancel(cmd.Context())
+	defer cancel()
+
+	probes, err := r.getMeasurementProbes(ctx, locations)
+	if err != nil {
+		return err
+	}
+	if probes == nil {
+		online, err := r.client.GetProbes(ctx)
+		if err != nil {
+			return err
+		}
+		for i := range online {
+			p := online[i].Location
+			p.Tags = online[i].Tags
+			if matchesLocations(&p, locations) {
+				probes = append(probes, p)
+			}
+		}
+	}
+
+	return view.OutputProbes(r.ctx, r.printer, view.NewProbesResult(probes))
+}
+
+// Measurement IDs are random alphanumeric strings
+var measurementIDRegexp = regexp.MustCompile(`^[A-Za-z0-9]{16,}$`)
+
+// Returns the probes used by the previous measurement selected by the location, or nil if the location is not a measurement.
+// A location which looks like a measurement ID but isn't one is matched against the online probes instead.
+func (r *Root) getMeasurementProbes(ctx context.Context, locations []globalping.Locations) ([]globalping.ProbeDetails, error) {
+	isID := len(locations) == 1 && measurementIDRegexp.MatchString(locations[0].Magic)
+	if !r.ctx.IsLocationFromSession && !isID {
+		return nil, nil
+	}
+	m, err := r.client.GetMeasurement(ctx, locations[0].Magic)
+	if err != nil {
+		notFoundErr := &globalping.NotFoundError{}
+		if !r.ctx.IsLocationFromSession && errors.As(err, &notFoundErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	probes := make([]globalping.ProbeDetails, len(m.Results))
+	for i := range m.Results {
+		probes[i] = m.Results[i].Probe
+	}
+	return probes, nil
+}
+
+// Returns true if the probe matches any of the locations
+func matchesLocations(p *globalping.ProbeDetails, locations []globalping.Locations) bool {
+	for i := range locations {
+		if matchesLocation(p, locations[i].Magic) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the probe matches all the "+" separated values of the location
+func matchesLocation(p *globalping.ProbeDetails, magic string) bool {
+	for _, v := range strings.Split(magic, "+") {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" || v == "world" {
+			continue
+		}
+		if !matchesLocationValue(p, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the probe matches the location value, see the help of the command for the matching rules
+func matchesLocationValue(p *globalping.ProbeDetails, v string) bool {
+	asn := strconv.Itoa(p.ASN)
+	if v == asn || v == "as"+asn {
+		return true
+	}
+	for _, code := range []string{p.Continent, p.Country, p.State} {
+		if code != "" && v == strings.ToLower(code) {
+			return true
+		}
+	}
+	for _, tag := range p.Tags {
+		tag = strings.ToLower(tag)
+		if v == tag || strings.HasPrefix(tag, v+"-") {
+			return true
+		}
+	}
+	words := locationWords(v)
+	if len(words) == 0 {
+		return false
+	}
+	names := []string{continentNames[p.Continent], p.Region}
+	names = append(names, countryNames[p.Country]...)
+	if p.Country == "US" {
+		names = append(names, usStateNames[p.State]...)
+	}
+	for _, name := range names {
+		if slices.Equal(locationWords(name), words) {
+			return true
+		}
+	}
+	for _, name := range []string{p.City, p.Network} {
+		if containsWords(locationWords(name), words) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the lowercase words of a name, separated by anything other than letters and digits
+func locationWords(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Returns true if the words contain the sub words in the same order
+func containsWords(words []string, sub []string) bool {
+	for i := 0; i+len(sub) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(sub)], sub) {
+			return true
+		}
+	}
+	return false
+}
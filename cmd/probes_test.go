@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var testProbes = []globalping.Probe{
+	{
+		Location: globalping.ProbeDetails{Continent: "EU", Region: "Western Europe", Country: "DE", City: "Berlin", ASN: 3320, Network: "Deutsche Telekom AG"},
+		Tags:     []string{"eyeball-network"},
+	},
+	{
+		Location: globalping.ProbeDetails{Continent: "NA", Region: "Northern America", Country: "US", State: "VA", City: "Ashburn", ASN: 16509, Network: "Amazon.com, Inc."},
+		Tags:     []string{"datacenter-network", "aws-us-east-1"},
+	},
+	{
+		Location: globalping.ProbeDetails{Continent: "EU", Region: "Western Europe", Country: "DE", City: "Frankfurt", ASN: 16509, Network: "Amazon.com, Inc."},
+		Tags:     []string{"datacenter-network", "aws-eu-central-1"},
+	},
+	{
+		Location: globalping.ProbeDetails{Continent: "EU", Region: "Eastern Europe", Country: "PL", City: "Warsaw", ASN: 5617, Network: "Orange Polska"},
+	},
+}
+
+func Test_Execute_Probes_Default(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetProbes(gomock.Any()).Times(1).Return(testProbes, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("probes")
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "probes", "europe+de,as5617"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `City       Country  Continent  ASN      Network              Tags
+Berlin     DE       EU         AS3320   Deutsche Telekom AG  eyeball-network
+Frankfurt  DE       EU         AS16509  Amazon.com, Inc.     datacenter-network, aws-eu-central-1
+Warsaw     PL       EU         AS5617   Orange Polska
+
+Continent  Probes
+EU         3
+
+Country  Probes
+DE       2
+PL       1
+
+Total: 3 probes
+`, w.String())
+}
+
+func Test_Execute_Probes_Json(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetProbes(gomock.Any()).Times(1).Return(testProbes, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("probes")
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "probes", "--from", "aws-us-east-1", "--json"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `{
+  "probes": [
+    {
+      "continent": "NA",
+      "region": "Northern America",
+      "country": "US",
+      "city": "Ashburn",
+      "state": "VA",
+      "asn": 16509,
+      "network": "Amazon.com, Inc.",
+      "tags": [
+        "datacenter-network",
+        "aws-us-east-1"
+      ]
+    }
+  ],
+  "continents": {
+    "NA": 1
+  },
+  "countries": {
+    "US": 1
+  },
+  "total": 1
+}
+`, w.String())
+}
+
+func Test_Execute_Probes_From_Session(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	err := saveIdToSession(measurementID1)
+	assert.NoError(t, err)
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(&globalping.Measurement{
+		Results: []globalping.ProbeMeasurement{{Probe: testProbes[3].Location}},
+	}, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("probes")
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "probes", "last"}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `City    Country  Continent  ASN     Network        Tags
+Warsaw  PL       EU         AS5617  Orange Polska
+
+Continent  Probes
+EU         1
+
+Country  Probes
+PL       1
+
+Total: 1 probe
+`, w.String())
+}
+
+func Test_Execute_Probes_None(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetProbes(gomock.Any()).Times(1).Return(testProbes, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("probes")
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "probes", "oceania"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "No probes found\n", w.String())
+}
+
+func Test_Execute_Probes_Measurement_ID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(&globalping.Measurement{
+		Results: []globalping.ProbeMeasurement{{Probe: testProbes[3].Location}},
+	}, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("probes")
+	ctx.ToJSON = true
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "probes", measurementID1}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Contains(t, w.String(), `"total": 1`)
+	assert.Contains(t, w.String(), `"city": "Warsaw"`)
+}
+
+func Test_Execute_Probes_Measurement_ID_Not_Found(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// A location which looks like a measurement ID is matched against the probes if no measurement exists
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), "datacenternetwork").Times(1).Return(nil, &globalping.NotFoundError{ID: "datacenternetwork"})
+	gbMock.EXPECT().GetProbes(gomock.Any()).Times(1).Return(testProbes, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("probes")
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "probes", "datacenternetwork"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, "No probes found\n", w.String())
+}
+
+func Test_MatchesLocationValue(t *testing.T) {
+	berlin := testProbes[0].Location
+	berlin.Tags = testProbes[0].Tags
+	ashburn := testProbes[1].Location
+	ashburn.Tags = testProbes[1].Tags
+
+	tests := []struct {
+		probe    *globalping.ProbeDetails
+		value    string
+		expected bool
+	}{
+		{&berlin, "eu", true},
+		{&berlin, "europe", true},
+		{&ashburn, "north america", true},
+		{&ashburn, "north-america", true},
+		{&ashburn, "america", false},
+		{&berlin, "western europe", true},
+		{&berlin, "western-europe", true},
+		{&berlin, "western", false},
+		{&berlin, "de", true},
+		{&berlin, "germany", true},
+		{&berlin, "Germany", true},
+		{&berlin, "poland", false},
+		{&ashburn, "united states", true},
+		{&ashburn, "usa", true},
+		{&ashburn, "virginia", true},
+		{&ashburn, "california", false},
+		{&globalping.ProbeDetails{Country: "US", State: "CA", City: "San Jose"}, "california", true},
+		{&globalping.ProbeDetails{Country: "GB", City: "London"}, "united-kingdom", true},
+		{&globalping.ProbeDetails{Country: "GB", City: "London"}, "uk", true},
+		{&ashburn, "va", true},
+		{&berlin, "as3320", true},
+		{&berlin, "3320", true},
+		{&berlin, "berlin", true},
+		{&berlin, "berl", false},
+		{&berlin, "telekom", true},
+		{&berlin, "deutsche telekom", true},
+		{&berlin, "tele", false},
+		// Partial words of the network don't match, e.g. us in Telus
+		{&globalping.ProbeDetails{Country: "CA", Network: "Telus Communications"}, "us", false},
+		{&ashburn, "amazon", true},
+		{&ashburn, "aws", true},
+		{&ashburn, "aws-us-east-1", true},
+		{&ashburn, "datacenter", true},
+		{&ashburn, "center", false},
+		{&ashburn, "us", true},
+		{&berlin, "us", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, matchesLocationValue(test.probe, test.value), test.value)
+	}
+}
@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 
 	"github.com/jsdelivr/globalping-cli/globalping"
@@ -21,8 +26,13 @@ type Root struct {
 	time    utils.Time
 	Cmd     *cobra.Command
 	cancel  chan os.Signal
+
+	outputFile *os.File // File opened with --output-file, nil if the results are written to stdout
 }
 
+// Directory where finished measurements are cached, caching to disk is disabled if unset
+const CacheDirEnvName = "GLOBALPING_CACHE_DIR"
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -34,15 +44,225 @@ func Execute() {
 		From:           "world",
 		Limit:          1,
 	}
-	globalpingClient := globalping.NewClient(globalping.API_URL)
+	token, err := getToken()
+	if err != nil {
+		printer.ErrPrintf("Warning: %s\n", err)
+	}
+	globalpingClient := globalping.NewClient(globalping.Config{
+		APIURL:       globalping.API_URL,
+		APIBaseURL:   globalping.API_BASE_URL,
+		AuthToken:    token,
+		MaxRetries:   globalping.API_MAX_RETRIES,
+		RetryBackoff: globalping.API_RETRY_BACKOFF,
+		CacheDir:     os.Getenv(CacheDirEnvName),
+		Logf: func(format string, args ...any) {
+			if ctx.Verbose {
+				printer.ErrPrintf(format+"\n", args...)
+			}
+		},
+	})
 	globalpingProbe := probe.NewProbe()
 	viewer := view.NewViewer(ctx, printer, utime, globalpingClient)
 	root := NewRoot(printer, ctx, viewer, utime, globalpingClient, globalpingProbe)
 
-	err := root.Cmd.Execute()
+	err = root.Cmd.Execute()
+	root.closeOutput() // The post run hook is skipped if the command failed
+	if err != nil {
+		os.Exit(exitCode(err))
+	}
+}
+
+// Returns a context that is canceled when the process receives SIGINT or SIGTERM,
+// or when the timeout set with --timeout is reached
+func (r *Root) contextWithCancel(parent context.Context) (context.Context, context.CancelFunc) {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if r.ctx.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(parent, r.ctx.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+	go func() {
+		select {
+		case <-r.cancel:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// Exit codes allowing scripts to tell the different classes of errors apart
+const (
+	ExitCodeError      = 1 // Generic error
+	ExitCodeValidation = 2 // The measurement options were rejected by the API
+	ExitCodeNoProbes   = 3 // No probes matched the requested locations
+	ExitCodeNotFound   = 4 // The measurement was not found
+	ExitCodeRateLimit  = 5 // The rate limit was exceeded
+	ExitCodeServer     = 6 // The API failed with an internal error
+	ExitCodeTimeout    = 7 // The timeout was reached before the measurement finished
+	ExitCodeAssertion  = 8 // The results didn't pass the assertions
+)
+
+func exitCode(err error) int {
+	var (
+		validationErr *globalping.ValidationError
+		noProbesErr   *globalping.NoProbesError
+		notFoundErr   *globalping.NotFoundError
+		rateLimitErr  *globalping.RateLimitError
+		serverErr     *globalping.ServerError
+	)
+	switch {
+	case errors.As(err, &validationErr):
+		return ExitCodeValidation
+	case errors.As(err, &noProbesErr):
+		return ExitCodeNoProbes
+	case errors.As(err, &notFoundErr):
+		return ExitCodeNotFound
+	case errors.As(err, &rateLimitErr):
+		return ExitCodeRateLimit
+	case errors.As(err, &serverErr):
+		return ExitCodeServer
+	case errors.Is(err, view.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return ExitCodeTimeout
+	case errors.Is(err, view.ErrAssertionFailed):
+		return ExitCodeAssertion
+	}
+	return ExitCodeError
+}
+
+// Returns true if the error is caused by the user input, in which case the usage is printed
+func isUsageError(err error) bool {
+	var (
+		validationErr *globalping.ValidationError
+		noProbesErr   *globalping.NoProbesError
+	)
+	return errors.As(err, &validationErr) || errors.As(err, &noProbesErr)
+}
+
+// Validates the output flags and opens the output file before any measurement is created
+func (r *Root) preRun(cmd *cobra.Command, args []string) error {
+	err := r.validateCommandFormat(cmd)
+	if err != nil {
+		return err
+	}
+	err = r.loadTemplate()
+	if err != nil {
+		return err
+	}
+	err = r.validateFormat(cmd)
+	if err != nil {
+		return err
+	}
+	return r.openOutputFile()
+}
+
+// Parses the template set with --template or --template-file, which selects the template format
+func (r *Root) loadTemplate() error {
+	text := r.ctx.TemplateText
+	if r.ctx.TemplateFile != "" {
+		if text != "" {
+			return errors.New("the --template and --template-file flags can't be used together")
+		}
+		b, err := os.ReadFile(r.ctx.TemplateFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the template file: %w", err)
+		}
+		text = string(b)
+	} else if text == "" {
+		if r.ctx.Format == view.FormatTemplate {
+			return errors.New("the template format requires the --template or --template-file flag")
+		}
+		return nil
+	}
+	if r.ctx.Format != "" && r.ctx.Format != view.FormatTemplate {
+		return fmt.Errorf("the --template and --template-file flags can't be used with --format %s", r.ctx.Format)
+	}
+	t, err := view.ParseTemplate(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	r.ctx.Format = view.FormatTemplate
+	r.ctx.Template = t
+	return nil
+}
+
+// The commands which output measurements, the other ones don't support the output formats
+var measurementCommands = []string{"ping", "traceroute", "dns", "mtr", "http", "show"}
+
+// The commands which don't output measurements but support the --json flag, and --format json
+var jsonCommands = []string{"limits", "probes"}
+
+// Rejects the --format, --template and --template-file flags for the commands which don't output measurements
+func (r *Root) validateCommandFormat(cmd *cobra.Command) error {
+	for cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	name := cmd.Name()
+	if slices.Contains(measurementCommands, name) {
+		return nil
+	}
+	hasTemplate := r.ctx.TemplateText != "" || r.ctx.TemplateFile != ""
+	if slices.Contains(jsonCommands, name) {
+		if hasTemplate || r.ctx.Format != "" && r.ctx.Format != view.FormatJSON {
+			return fmt.Errorf("the %s command only supports --format json", name)
+		}
+		return nil
+	}
+	if hasTemplate || r.ctx.Format != "" {
+		return fmt.Errorf("the %s command doesn't support the --format, --template and --template-file flags", name)
+	}
+	return nil
+}
+
+// Rejects the unsupported values of the --format flag
+func (r *Root) validateFormat(cmd *cobra.Command) error {
+	if r.ctx.Format == "" {
+		return nil
+	}
+	name := cmd.Name()
+	if name == "show" {
+		// The command of a stored measurement is only known once it's fetched
+		name = ""
+	}
+	err := view.ValidateFormat(r.ctx.Format, name)
 	if err != nil {
-		os.Exit(1)
+		return err
+	}
+	if r.ctx.Format == view.FormatJSON {
+		// The commands outputting other data than measurements only check the --json flag
+		r.ctx.ToJSON = true
 	}
+	return nil
+}
+
+// Redirects the output to the file set with --output-file, appending to it if it exists.
+// The output written to a file doesn't contain realtime updates and colors.
+// The openmetrics format replaces the file with every exposition instead, so it's not opened.
+func (r *Root) openOutputFile() error {
+	if r.ctx.OutputFile == "" || r.ctx.Format == view.FormatOpenMetrics {
+		return nil
+	}
+	f, err := os.OpenFile(r.ctx.OutputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open the output file: %w", err)
+	}
+	r.outputFile = f
+	r.printer.OutWriter = f
+	return nil
+}
+
+// Ends the output by closing the html report and the file opened with --output-file
+func (r *Root) closeOutput() error {
+	view.CloseHTMLReport(r.ctx, r.printer)
+	if r.outputFile == nil {
+		return nil
+	}
+	err := r.outputFile.Close()
+	r.outputFile = nil
+	return err
 }
 
 func NewRoot(
@@ -73,6 +293,10 @@ func NewRoot(
 The CLI tool allows you to interact with the API in a simple and human-friendly way to debug networking issues like anycast routing and script automated tests and benchmarks.`,
 	}
 
+	root.Cmd.PersistentPreRunE = root.preRun
+	root.Cmd.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
+		return root.closeOutput()
+	}
 	root.Cmd.SetOut(printer.OutWriter)
 	root.Cmd.SetErr(printer.ErrWriter)
 	// Global flags
@@ -83,8 +307,17 @@ The CLI tool allows you to interact with the API in a simple and human-friendly
 	flags.IntVarP(&ctx.Limit, "limit", "L", ctx.Limit, "Limit the number of probes to use")
 	flags.BoolVarP(&ctx.ToJSON, "json", "J", ctx.ToJSON, "Output results in JSON format (default false)")
 	flags.BoolVarP(&ctx.CIMode, "ci", "C", ctx.CIMode, "Disable realtime terminal updates and color suitable for CI and scripting (default false)")
-	flags.BoolVar(&ctx.ToLatency, "latency", ctx.ToLatency, "Output only the stats of a measurement (default false). Only applies to the dns, http and ping commands")
+	flags.BoolVar(&ctx.ToLatency, "latency", ctx.ToLatency, "Output only the stats of a measurement (default false). Only applies to the dns, http, mtr, ping and traceroute commands")
+	flags.StringVar(&ctx.Format, "format", ctx.Format, "Output the results in the given format ("+strings.Join(view.FormatNames(), ", ")+"). The csv and tsv formats output one row per probe or per hop for the mtr and traceroute commands, the influx line protocol and openmetrics only apply to the dns, http and ping commands, the table format to the dns, mtr and traceroute commands and the tls format to the http command")
+	flags.StringVar(&ctx.TemplateText, "template", ctx.TemplateText, "Output the results using the given Go template, executed against the measurement with the decoded stats, timings and hops of every probe, e.g. '{{range .Results}}{{.Probe.City}} {{.Stats.Avg}}{{\"\\n\"}}{{end}}'")
+	flags.StringVar(&ctx.TemplateFile, "template-file", ctx.TemplateFile, "Output the results using the Go template in the given file")
+	flags.StringVar(&ctx.OutputFile, "output-file", ctx.OutputFile, "Append the results to the given file instead of printing them. The openmetrics format atomically replaces the file with the results of the last measurement")
+	flags.StringVar(&ctx.JUnitFile, "junit", ctx.JUnitFile, "Write a JUnit XML report to the given file, with a test case per probe failing if the probe didn't finish or an assertion failed")
+	flags.BoolVar(&ctx.ToTable, "table", ctx.ToTable, "Output the structured results as tables (default false). Only applies to the dns, mtr and traceroute commands")
 	flags.BoolVar(&ctx.Share, "share", ctx.Share, "Prints a link at the end the results, allowing to vizualize the results online (default false)")
+	flags.BoolVar(&ctx.WaitOnLimit, "wait-on-limit", ctx.WaitOnLimit, "Wait for the rate limit to reset and retry instead of failing when it is exceeded (default false)")
+	flags.BoolVarP(&ctx.Verbose, "verbose", "v", ctx.Verbose, "Log additional details such as retried API requests to stderr (default false)")
+	flags.DurationVar(&ctx.Timeout, "timeout", ctx.Timeout, "Stop waiting for the measurement after the given duration (e.g. 30s) and output the partial results (default no timeout)")
 
 	root.Cmd.AddGroup(&cobra.Group{ID: "Measurements", Title: "Measurement Commands:"})
 
@@ -96,6 +329,10 @@ The CLI tool allows you to interact with the API in a simple and human-friendly
 	root.initInstallProbe()
 	root.initVersion()
 	root.initHistory()
+	root.initAuth()
+	root.initLimits()
+	root.initProbes()
+	root.initShow()
 
 	return root
 }
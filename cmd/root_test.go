@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_ExitCode(t *testing.T) {
+	assert.Equal(t, ExitCodeError, exitCode(errors.New("error")))
+	assert.Equal(t, ExitCodeValidation, exitCode(&globalping.ValidationError{}))
+	assert.Equal(t, ExitCodeNoProbes, exitCode(&globalping.NoProbesError{}))
+	assert.Equal(t, ExitCodeNotFound, exitCode(&globalping.NotFoundError{}))
+	assert.Equal(t, ExitCodeRateLimit, exitCode(&globalping.RateLimitError{}))
+	assert.Equal(t, ExitCodeServer, exitCode(fmt.Errorf("failed to get data: %w", &globalping.ServerError{})))
+	assert.Equal(t, ExitCodeTimeout, exitCode(view.ErrTimeout))
+	assert.Equal(t, ExitCodeTimeout, exitCode(context.DeadlineExceeded))
+	assert.Equal(t, ExitCodeAssertion, exitCode(fmt.Errorf("%w: 1 of 2 checks failed", view.ErrAssertionFailed)))
+}
+
+func Test_IsUsageError(t *testing.T) {
+	assert.True(t, isUsageError(&globalping.ValidationError{}))
+	assert.True(t, isUsageError(&globalping.NoProbesError{}))
+	assert.False(t, isUsageError(&globalping.ServerError{}))
+	assert.False(t, isUsageError(errors.New("error")))
+}
+
+func Test_Execute_Invalid_Format(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--format", "xml"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.ErrorIs(t, err, view.ErrUnsupportedFormat)
+	assert.EqualError(t, err, "unsupported output format: xml")
+}
+
+func Test_Execute_Influx_Unsupported_Command(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("mtr")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "mtr", "jsdelivr.com", "--format", "influx"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.ErrorIs(t, err, view.ErrUnsupportedFormat)
+	assert.EqualError(t, err, "unsupported output format: influx is only supported by the ping, dns and http commands")
+}
+
+func Test_Execute_Format_Non_Measurement_Commands(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"version", "--format", "ndjson"}, "the version command doesn't support the --format, --template and --template-file flags"},
+		{[]string{"auth", "status", "--format", "html"}, "the auth command doesn't support the --format, --template and --template-file flags"},
+		{[]string{"history", "--template", "{{.ID}}"}, "the history command doesn't support the --format, --template and --template-file flags"},
+		{[]string{"install-probe", "--format", "json"}, "the install-probe command doesn't support the --format, --template and --template-file flags"},
+		{[]string{"limits", "--format", "csv"}, "the limits command only supports --format json"},
+		{[]string{"probes", "--template", "{{.ID}}"}, "the probes command only supports --format json"},
+	}
+	for _, test := range tests {
+		w := new(bytes.Buffer)
+		printer := view.NewPrinter(nil, w, w)
+		ctx := createDefaultContext("")
+		root := NewRoot(printer, ctx, nil, nil, nil, nil)
+		os.Args = append([]string{"globalping"}, test.args...)
+		err := root.Cmd.ExecuteContext(context.TODO())
+		assert.EqualError(t, err, test.expected, test.args)
+	}
+}
+
+func Test_Execute_Template_File(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+
+	templateFile := filepath.Join(t.TempDir(), "output.tmpl")
+	err := os.WriteFile(templateFile, []byte("{{.Target}}"), 0644)
+	assert.NoError(t, err)
+
+	err = root.loadTemplate()
+	assert.NoError(t, err)
+	assert.Nil(t, ctx.Template)
+
+	ctx.TemplateFile = templateFile
+	err = root.loadTemplate()
+	assert.NoError(t, err)
+	assert.Equal(t, view.FormatTemplate, ctx.Format)
+	assert.NotNil(t, ctx.Template)
+
+	ctx.TemplateText = "{{.ID}}"
+	err = root.loadTemplate()
+	assert.EqualError(t, err, "the --template and --template-file flags can't be used together")
+}
+
+func Test_Execute_Template_Invalid(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--template", "{{.Target"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.ErrorContains(t, err, "invalid template: ")
+
+	ctx = createDefaultContext("ping")
+	root = NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--template", "{{.Target}}", "--format", "csv"}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the --template and --template-file flags can't be used with --format csv")
+
+	ctx = createDefaultContext("ping")
+	root = NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--format", "template"}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the template format requires the --template or --template-file flag")
+}
+
+func Test_Execute_Output_File(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	outputFile := filepath.Join(t.TempDir(), "results.txt")
+	err := os.WriteFile(outputFile, []byte("previous results\n"), 0644)
+	assert.NoError(t, err)
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations[0].Magic = "world"
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(createDefaultMeasurementCreateResponse(), nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).DoAndReturn(
+		func(_ context.Context, _ string, _ *globalping.MeasurementCreate) error {
+			printer.Println("new results")
+			return nil
+		})
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	ctx := createDefaultContext("ping")
+	root := NewRoot(printer, ctx, viewerMock, timeMock, gbMock, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--output-file", outputFile}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", w.String())
+	assert.True(t, ctx.CIMode)
+	assert.Nil(t, root.outputFile)
+
+	b, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "previous results\nnew results\n", string(b))
+}
+
+func Test_Execute_Output_File_OpenMetrics(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	outputFile := filepath.Join(t.TempDir(), "globalping.prom")
+	err := os.WriteFile(outputFile, []byte("previous results\n"), 0644)
+	assert.NoError(t, err)
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations[0].Magic = "world"
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(createDefaultMeasurementCreateResponse(), nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("ping")
+	var root *Root
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).DoAndReturn(
+		func(_ context.Context, _ string, _ *globalping.MeasurementCreate) error {
+			// The viewer replaces the file itself, it's not opened for appending
+			assert.Nil(t, root.outputFile)
+			assert.Equal(t, outputFile, ctx.OutputFile)
+			return nil
+		})
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	root = NewRoot(printer, ctx, viewerMock, timeMock, gbMock, nil)
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--format", "openmetrics", "--output-file", outputFile}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "previous results\n", string(b))
+}
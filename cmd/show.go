@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/spf13/cobra"
+)
+
+func (r *Root) initShow() {
+	showCmd := &cobra.Command{
+		RunE:    r.RunShow,
+		Use:     "show [measurement ID | @1 | first | @-1 | last | previous]",
+		Aliases: []string{"get"},
+		Short:   "Show the results of an existing measurement",
+		Long: `Fetch and show the results of existing measurements, using the same output as the command which created them.
+Multiple measurement IDs can be combined with "+" to show them one after another.
+
+Examples:
+  # Show the results of a measurement
+  show rvasVvKnj48cxNjC
+
+  # Show the results of two measurements
+  show rvasVvKnj48cxNjC+nZ3a2tYsRMdLGuuO
+
+  # Show the results of the last measurement in session
+  show last
+
+  # Show the results of the second to last measurement in session with latency output
+  show @-2 --latency
+
+  # Render the results of the last measurement as a markdown report
+  show last --format markdown
+
+  # Show the results of the first measurement in session in JSON format
+  show first --json`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	r.Cmd.AddCommand(showCmd)
+}
+
+func (r *Root) RunShow(cmd *cobra.Command, args []string) error {
+	ids, err := getMeasurementIds(args[0])
+	if err != nil {
+		return err
+	}
+	if len(ids) > 1 && r.ctx.Format == view.FormatOpenMetrics {
+		// An exposition must contain a single set of metric families
+		return errors.New("the openmetrics format only supports a single measurement")
+	}
+	err = r.updateCIMode()
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancel := r.contextWithCancel(cmd.Context())
+	defer cancel()
+
+	for _, id := range ids {
+		m, err := r.client.GetMeasurement(ctx, id)
+		if err != nil {
+			return err
+		}
+		// The output depends on the command which created the measurement
+		r.ctx.Cmd = m.Type
+		r.ctx.Target = m.Target
+		opts := &globalping.MeasurementCreate{
+			Type:   m.Type,
+			Target: m.Target,
+			Limit:  m.ProbesCount,
+		}
+		err = r.viewer.Output(ctx, id, opts)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the measurement IDs from a "+" separated list of IDs or session shortcuts
+func getMeasurementIds(s string) ([]string, error) {
+	var ids []string
+	for _, v := range strings.Split(s, "+") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		id, err := mapFromSession(v)
+		if err != nil {
+			return nil, err
+		}
+		if id == "" {
+			id = v
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("provided measurement ID is empty")
+	}
+	return ids, nil
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Execute_Show_Default(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createDefaultMeasurement("dns")
+	measurement.Target = "jsdelivr.com"
+	expectedOpts := &globalping.MeasurementCreate{
+		Type:   "dns",
+		Target: "jsdelivr.com",
+		Limit:  1,
+	}
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, expectedOpts).Times(1).Return(nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("show")
+	root := NewRoot(printer, ctx, viewerMock, nil, gbMock, nil)
+	os.Args = []string{"globalping", "show", measurementID1}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", w.String())
+	assert.Equal(t, "dns", ctx.Cmd)
+	assert.Equal(t, "jsdelivr.com", ctx.Target)
+	assert.True(t, ctx.CIMode)
+}
+
+func Test_Execute_Show_Multiple_From_Session(t *testing.T) {
+	t.Cleanup(sessionCleanup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	err := saveIdToSession(measurementID1)
+	assert.NoError(t, err)
+	err = saveIdToSession(measurementID2)
+	assert.NoError(t, err)
+
+	measurement1 := createDefaultMeasurement("ping")
+	measurement2 := createDefaultMeasurement("mtr")
+	measurement2.ID = measurementID2
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID2).Times(1).Return(measurement2, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement1, nil)
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	gomock.InOrder(
+		viewerMock.EXPECT().Output(gomock.Any(), measurementID2, &globalping.MeasurementCreate{Type: "mtr", Limit: 1}).Times(1).Return(nil),
+		viewerMock.EXPECT().Output(gomock.Any(), measurementID1, &globalping.MeasurementCreate{Type: "ping", Limit: 1}).Times(1).Return(nil),
+	)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("get")
+	root := NewRoot(printer, ctx, viewerMock, nil, gbMock, nil)
+	os.Args = []string{"globalping", "get", "last+@1"}
+	err = root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "ping", ctx.Cmd)
+}
+
+func Test_Execute_Show_OpenMetrics_Multiple(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("show")
+	root := NewRoot(printer, ctx, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "show", measurementID1 + "+" + measurementID2, "--format", "openmetrics"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.EqualError(t, err, "the openmetrics format only supports a single measurement")
+}
+
+func Test_Execute_Show_Not_Found(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notFoundErr := &globalping.NotFoundError{}
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(nil, notFoundErr)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("show")
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil)
+	os.Args = []string{"globalping", "show", measurementID1}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.ErrorIs(t, err, notFoundErr)
+	assert.Equal(t, ExitCodeNotFound, exitCode(err))
+}
+
+func Test_Execute_Show_HTML_Closed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createDefaultMeasurement("ping")
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext("show")
+
+	viewerMock := mocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().Output(gomock.Any(), measurementID1, gomock.Any()).Times(1).DoAndReturn(
+		func(_ context.Context, _ string, _ *globalping.MeasurementCreate) error {
+			// The viewer starts the html report
+			ctx.IsHTMLReportOpen = true
+			printer.Println("<section></section>")
+			return nil
+		})
+
+	root := NewRoot(printer, ctx, viewerMock, nil, gbMock, nil)
+	os.Args = []string{"globalping", "show", measurementID1, "--format", "html"}
+	err := root.Cmd.ExecuteContext(context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "<section></section>\n</body>\n</html>\n", w.String())
+	assert.False(t, ctx.IsHTMLReportOpen)
+}
@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/jsdelivr/globalping-cli/globalping"
-	"github.com/jsdelivr/globalping-cli/view"
 	"github.com/spf13/cobra"
 )
 
@@ -41,6 +38,12 @@ Examples:
   # Traceroute jsdelivr.com from a probe that is located in Paris to port 453
   traceroute jsdelivr.com from Paris --port 453
 
+  # Traceroute jsdelivr.com from 2 probes in Europe with the hops as aligned tables
+  traceroute jsdelivr.com from Europe --limit 2 --table
+
+  # Traceroute jsdelivr.com from a probe in Germany with latency output
+  traceroute jsdelivr.com from Germany --latency
+
   # Traceroute jsdelivr.com from a probe in ASN 123 with json output
   traceroute jsdelivr.com from 123 --json`,
 	}
@@ -59,10 +62,6 @@ func (r *Root) RunTraceroute(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if r.ctx.ToLatency {
-		return fmt.Errorf("the latency flag is not supported by the traceroute command")
-	}
-
 	defer r.UpdateHistory()
 	r.ctx.RecordToSession = true
 
@@ -82,29 +81,17 @@ func (r *Root) RunTraceroute(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	res, showHelp, err := r.client.CreateMeasurement(opts)
+	ctx, cancel := r.contextWithCancel(cmd.Context())
+	defer cancel()
+
+	hm, err := r.createMeasurement(ctx, opts)
 	if err != nil {
-		if !showHelp {
-			cmd.SilenceUsage = true
-		}
 		return err
 	}
 
-	r.ctx.MeasurementsCreated++
-	hm := &view.HistoryItem{
-		Id:        res.ID,
-		Status:    globalping.StatusInProgress,
-		StartedAt: r.time.Now(),
-	}
-	r.ctx.History.Push(hm)
-	if r.ctx.RecordToSession {
-		r.ctx.RecordToSession = false
-		err := saveIdToSession(res.ID)
-		if err != nil {
-			r.printer.Printf("Warning: %s\n", err)
-		}
+	err = r.viewer.Output(ctx, hm.Id, opts)
+	if err != nil {
+		cmd.SilenceUsage = true
 	}
-
-	r.viewer.Output(res.ID, opts)
-	return nil
+	return err
 }
@@ -0,0 +1,204 @@
+package globalping
+
+import (
+	"container/list"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	DEFAULT_CACHE_SIZE      = 100
+	DEFAULT_DISK_CACHE_SIZE = 1000
+)
+
+type cacheEntry struct {
+	id       string
+	etag     string
+	body     []byte
+	finished bool // The measurement can no longer change
+}
+
+// Thread-safe LRU cache of measurement responses keyed by measurement id
+type cache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // Most recently used entries first
+	entries map[string]*list.Element
+}
+
+func newCache(size int) *cache {
+	if size <= 0 {
+		size = DEFAULT_CACHE_SIZE
+	}
+	return &cache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Returns a copy of the entry cached for the measurement id
+func (c *cache) Get(id string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[id]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	c.order.MoveToFront(el)
+	return *el.Value.(*cacheEntry), true
+}
+
+// Stores the entry, evicting the least recently used one if the cache is full
+func (c *cache) Set(e cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[e.id]; ok {
+		*el.Value.(*cacheEntry) = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[e.id] = c.order.PushFront(&e)
+	for c.order.Len() > c.size {
+		last := c.order.Back()
+		c.order.Remove(last)
+		delete(c.entries, last.Value.(*cacheEntry).id)
+	}
+}
+
+func (c *cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Returns the finished measurement stored in the cache directory
+func (c *client) readDiskCache(id string) ([]byte, bool) {
+	path, ok := c.diskCachePath(id)
+	if !ok {
+		return nil, false
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			c.log("failed to read cached measurement %s: %s", id, err)
+		}
+		return nil, false
+	}
+	// Keep recently read measurements from being pruned
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return b, true
+}
+
+// Stores the finished measurement in the cache directory
+func (c *client) writeDiskCache(id string, body []byte) {
+	path, ok := c.diskCachePath(id)
+	if !ok {
+		return
+	}
+	err := c.writeFileAtomic(path, body)
+	if err != nil {
+		c.log("failed to cache measurement %s: %s", id, err)
+		return
+	}
+	err = c.pruneDiskCache()
+	if err != nil {
+		c.log("failed to prune the measurement cache: %s", err)
+	}
+}
+
+// Removes the least recently used measurements once the cache directory holds more than the maximum
+func (c *client) pruneDiskCache() error {
+	entries, err := os.ReadDir(c.cacheDir)
+	if err != nil {
+		return err
+	}
+	type cachedFile struct {
+		name    string
+		modTime time.Time
+	}
+	files := []cachedFile{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue // Removed concurrently
+		}
+		files = append(files, cachedFile{e.Name(), info.ModTime()})
+	}
+	if len(files) <= c.cacheDirSize {
+		return nil
+	}
+	slices.SortFunc(files, func(a, b cachedFile) int {
+		return a.modTime.Compare(b.modTime)
+	})
+	for _, f := range files[:len(files)-c.cacheDirSize] {
+		err := os.Remove(filepath.Join(c.cacheDir, f.name))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes to a temporary file first so concurrent readers never see a partial file
+func (c *client) writeFileAtomic(path string, body []byte) error {
+	err := os.MkdirAll(c.cacheDir, 0700)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(c.cacheDir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+func (c *client) diskCachePath(id string) (string, bool) {
+	if c.cacheDir == "" || !isValidID(id) {
+		return "", false
+	}
+	return filepath.Join(c.cacheDir, id+".json"), true
+}
+
+// Measurement ids are alphanumeric, anything else must not be used as a file name
+func isValidID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the measurement in the response can no longer change
+func isFinished(body []byte) bool {
+	m := struct {
+		Status MeasurementStatus `json:"status"`
+	}{}
+	err := json.Unmarshal(body, &m)
+	return err == nil && m.Status != "" && m.Status != StatusInProgress
+}
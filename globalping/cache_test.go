@@ -0,0 +1,102 @@
+package globalping
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Cache_Evicts_Least_Recently_Used(t *testing.T) {
+	c := newCache(2)
+	c.Set(cacheEntry{id: "a", etag: "etag-a"})
+	c.Set(cacheEntry{id: "b", etag: "etag-b"})
+
+	_, ok := c.Get("a") // a is now the most recently used
+	assert.True(t, ok)
+
+	c.Set(cacheEntry{id: "c", etag: "etag-c"})
+	assert.Equal(t, 2, c.Len())
+
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	e, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "etag-a", e.etag)
+
+	e, ok = c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, "etag-c", e.etag)
+}
+
+func Test_Cache_Update(t *testing.T) {
+	c := newCache(2)
+	c.Set(cacheEntry{id: "a", etag: "etag-1"})
+	c.Set(cacheEntry{id: "a", etag: "etag-2", finished: true})
+	assert.Equal(t, 1, c.Len())
+
+	e, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, cacheEntry{id: "a", etag: "etag-2", finished: true}, e)
+}
+
+func Test_Cache_Default_Size(t *testing.T) {
+	c := newCache(0)
+	assert.Equal(t, DEFAULT_CACHE_SIZE, c.size)
+}
+
+func Test_Cache_Concurrent(t *testing.T) {
+	c := newCache(10)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("id%d", i%20)
+			c.Set(cacheEntry{id: id})
+			c.Get(id)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 10, c.Len())
+}
+
+func Test_DiskCache_Prunes_Least_Recently_Used(t *testing.T) {
+	dir := t.TempDir()
+	c := NewClient(Config{CacheDir: dir, CacheDirSize: 2}).(*client)
+
+	age := func(id string, d time.Duration) {
+		mtime := time.Now().Add(-d)
+		assert.NoError(t, os.Chtimes(filepath.Join(dir, id+".json"), mtime, mtime))
+	}
+	c.writeDiskCache("a", []byte(`{"id":"a"}`))
+	age("a", 2*time.Hour)
+	c.writeDiskCache("b", []byte(`{"id":"b"}`))
+	age("b", time.Hour)
+
+	// Reading a measurement marks it as recently used
+	_, ok := c.readDiskCache("a")
+	assert.True(t, ok)
+
+	c.writeDiskCache("c", []byte(`{"id":"c"}`))
+
+	_, ok = c.readDiskCache("b")
+	assert.False(t, ok)
+	for _, id := range []string{"a", "c"} {
+		b, ok := c.readDiskCache(id)
+		assert.True(t, ok)
+		assert.Equal(t, `{"id":"`+id+`"}`, string(b))
+	}
+}
+
+func Test_IsValidID(t *testing.T) {
+	assert.True(t, isValidID("nzGzfAGL7sZfUs3c"))
+	assert.False(t, isValidID(""))
+	assert.False(t, isValidID("../config"))
+	assert.False(t, isValidID("a/b"))
+}
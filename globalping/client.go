@@ -1,31 +1,71 @@
 package globalping
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"time"
 )
 
 type Client interface {
-	CreateMeasurement(measurement *MeasurementCreate) (*MeasurementCreateResponse, bool, error)
-	GetMeasurement(id string) (*Measurement, error)
-	GetMeasurementRaw(id string) ([]byte, error)
+	CreateMeasurement(ctx context.Context, measurement *MeasurementCreate) (*MeasurementCreateResponse, error)
+	GetMeasurement(ctx context.Context, id string) (*Measurement, error)
+	GetMeasurementRaw(ctx context.Context, id string) ([]byte, error)
+	GetLimits(ctx context.Context) (*LimitsResponse, error)
+	GetProbes(ctx context.Context) ([]Probe, error)
+}
+
+type Config struct {
+	APIURL    string // The measurements endpoint of the api, e.g. API_URL
+	AuthToken string // Optional token used to authenticate the requests
+
+	APIBaseURL string // The base url of the other endpoints of the api, e.g. API_BASE_URL. Derived from APIURL if unset
+
+	MaxRetries   int                              // Number of times a failed request is retried, 0 disables retries
+	RetryBackoff time.Duration                    // Delay before the first retry, doubled after every attempt
+	Logf         func(format string, args ...any) // Optional logger used to report retries
+
+	CacheSize int    // Maximum number of measurements kept in memory, defaults to DEFAULT_CACHE_SIZE
+	CacheDir  string // Optional directory where finished measurements are stored
+
+	CacheDirSize int // Maximum number of measurements stored in CacheDir, defaults to DEFAULT_DISK_CACHE_SIZE
 }
 
 type client struct {
-	http   *http.Client
-	apiUrl string // The api url endpoint
+	http      *http.Client
+	apiUrl    string // The api url endpoint
+	baseUrl   string // The base url of the limits and probes endpoints
+	authToken string // The token sent in the Authorization header
+
+	maxRetries   int
+	retryBackoff time.Duration
+	logf         func(format string, args ...any)
+
+	cache    *cache // caches responses and their ETags by measurement id
+	cacheDir string // stores finished measurements on disk if set
 
-	etags        map[string]string // caches Etags by measurement id
-	measurements map[string][]byte // caches Measurements by ETag
+	cacheDirSize int // maximum number of measurements stored in cacheDir
 }
 
-func NewClient(url string) Client {
+func NewClient(config Config) Client {
+	if config.CacheDirSize <= 0 {
+		config.CacheDirSize = DEFAULT_DISK_CACHE_SIZE
+	}
+	if config.APIBaseURL == "" {
+		config.APIBaseURL = strings.TrimSuffix(config.APIURL, "/measurements")
+	}
 	return &client{
 		http: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		apiUrl:       url,
-		etags:        map[string]string{},
-		measurements: map[string][]byte{},
+		apiUrl:       config.APIURL,
+		baseUrl:      config.APIBaseURL,
+		authToken:    config.AuthToken,
+		maxRetries:   config.MaxRetries,
+		retryBackoff: config.RetryBackoff,
+		logf:         config.Logf,
+		cache:        newCache(config.CacheSize),
+		cacheDir:     config.CacheDir,
+		cacheDirSize: config.CacheDirSize,
 	}
 }
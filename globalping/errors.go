@@ -0,0 +1,94 @@
+package globalping
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NotFoundError is returned when the requested measurement does not exist.
+type NotFoundError struct {
+	ID string // The ID of the measurement.
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("measurement %s not found", e.ID)
+}
+
+// ValidationError is returned when the API rejects the measurement options.
+type ValidationError struct {
+	Message string            // The message returned by the API.
+	Params  map[string]string // The validation message of each invalid parameter, keyed by parameter name.
+}
+
+func (e *ValidationError) Error() string {
+	keys := make([]string, 0, len(e.Params))
+	for k := range e.Params {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	var b strings.Builder
+	b.WriteString("invalid parameters\n")
+	for _, k := range keys {
+		b.WriteString(" - " + e.Params[k] + "\n")
+	}
+	b.WriteString("Please check the help for more information")
+	return b.String()
+}
+
+// NoProbesError is returned when no probes match the requested locations.
+type NoProbesError struct {
+	Message string // The message returned by the API.
+}
+
+func (e *NoProbesError) Error() string {
+	return "no suitable probes found - please choose a different location"
+}
+
+// ServerError is returned when the API fails with a 5xx status code.
+type ServerError struct {
+	StatusCode int    // The HTTP status code of the response.
+	Message    string // The message returned by the API, if any.
+}
+
+func (e *ServerError) Error() string {
+	return "internal server error - please try again later"
+}
+
+// RateLimitError is returned when the API rejects a request because the rate limit was exceeded.
+type RateLimitError struct {
+	Limit     int64         // The number of requests allowed in the current period, -1 if unknown.
+	Remaining int64         // The number of requests remaining in the current period, -1 if unknown.
+	Reset     time.Duration // The time until the limit resets.
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded - please try again in %s or authenticate to get higher limits", e.Reset)
+}
+
+// Builds a RateLimitError from the X-RateLimit-* and Retry-After response headers
+func newRateLimitError(h http.Header) *RateLimitError {
+	err := &RateLimitError{
+		Limit:     parseIntHeader(h, "X-RateLimit-Limit"),
+		Remaining: parseIntHeader(h, "X-RateLimit-Remaining"),
+	}
+	reset := parseIntHeader(h, "Retry-After")
+	if reset < 0 {
+		reset = parseIntHeader(h, "X-RateLimit-Reset")
+	}
+	if reset > 0 {
+		err.Reset = time.Duration(reset) * time.Second
+	}
+	return err
+}
+
+func parseIntHeader(h http.Header, key string) int64 {
+	v, err := strconv.ParseInt(h.Get(key), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return v
+}
@@ -2,11 +2,14 @@ package globalping
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/andybalholm/brotli"
@@ -15,32 +18,46 @@ import (
 
 var (
 	API_URL          = "https://api.globalping.io/v1/measurements"
+	API_BASE_URL     = "https://api.globalping.io/v1"
 	API_MIN_INTERVAL = 500 * time.Millisecond
+
+	API_MAX_RETRIES     = 3
+	API_RETRY_BACKOFF   = 500 * time.Millisecond
+	API_MAX_RETRY_DELAY = 10 * time.Second
 )
 
-// boolean indicates whether to print CLI help on error
-func (c *client) CreateMeasurement(measurement *MeasurementCreate) (*MeasurementCreateResponse, bool, error) {
+// CreateMeasurement creates a new measurement and returns its ID
+//
+// Errors returned by the API are mapped to NoProbesError, ValidationError, RateLimitError and ServerError.
+func (c *client) CreateMeasurement(ctx context.Context, measurement *MeasurementCreate) (*MeasurementCreateResponse, error) {
 	postData, err := json.Marshal(measurement)
 	if err != nil {
-		return nil, false, errors.New("failed to marshal post data - please report this bug")
+		return nil, errors.New("failed to marshal post data - please report this bug")
 	}
 
 	// Create a new request
-	req, err := http.NewRequest("POST", c.apiUrl, bytes.NewBuffer(postData))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiUrl, bytes.NewBuffer(postData))
 	if err != nil {
-		return nil, false, errors.New("failed to create request - please report this bug")
+		return nil, errors.New("failed to create request - please report this bug")
 	}
-	req.Header.Set("User-Agent", userAgent())
-	req.Header.Set("Accept-Encoding", "br")
+	c.setHeaders(req)
 	req.Header.Set("Content-Type", "application/json")
 
 	// Make the request
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return nil, false, errors.New("request failed - please try again later")
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, errors.New("request failed - please try again later")
 	}
 	defer resp.Body.Close()
 
+	// 429 error
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, newRateLimitError(resp.Header)
+	}
+
 	// If an error is returned
 	if resp.StatusCode != http.StatusAccepted {
 		// Decode the response body as JSON
@@ -48,30 +65,33 @@ func (c *client) CreateMeasurement(measurement *MeasurementCreate) (*Measurement
 
 		err = json.NewDecoder(resp.Body).Decode(&data)
 		if err != nil {
-			return nil, false, errors.New("invalid error format returned - please report this bug")
+			if resp.StatusCode >= 500 {
+				return nil, &ServerError{StatusCode: resp.StatusCode}
+			}
+			return nil, errors.New("invalid error format returned - please report this bug")
 		}
 
 		// 422 error
 		if data.Error.Type == "no_probes_found" {
-			return nil, true, errors.New("no suitable probes found - please choose a different location")
+			return nil, &NoProbesError{Message: data.Error.Message}
 		}
 
 		// 400 error
 		if data.Error.Type == "validation_error" {
-			resErr := ""
-			for _, v := range data.Error.Params {
-				resErr += fmt.Sprintf(" - %s\n", v)
+			params := make(map[string]string, len(data.Error.Params))
+			for k, v := range data.Error.Params {
+				params[k] = fmt.Sprint(v)
 			}
-			return nil, true, fmt.Errorf("invalid parameters\n%sPlease check the help for more information", resErr)
+			return nil, &ValidationError{Message: data.Error.Message, Params: params}
 		}
 
 		// 500 error
-		if data.Error.Type == "api_error" {
-			return nil, false, errors.New("internal server error - please try again later")
+		if data.Error.Type == "api_error" || resp.StatusCode >= 500 {
+			return nil, &ServerError{StatusCode: resp.StatusCode, Message: data.Error.Message}
 		}
 
 		// If the error type is unknown
-		return nil, false, fmt.Errorf("unknown error response: %s", data.Error.Type)
+		return nil, fmt.Errorf("unknown error response: %s", data.Error.Type)
 	}
 
 	// Read the response body
@@ -84,15 +104,15 @@ func (c *client) CreateMeasurement(measurement *MeasurementCreate) (*Measurement
 	res := &MeasurementCreateResponse{}
 	err = json.NewDecoder(bodyReader).Decode(res)
 	if err != nil {
-		return nil, false, fmt.Errorf("invalid post measurement format returned - please report this bug: %s", err)
+		return nil, fmt.Errorf("invalid post measurement format returned - please report this bug: %s", err)
 	}
 
-	return res, false, nil
+	return res, nil
 }
 
 // GetRawMeasurement returns API response as a GetMeasurement object
-func (c *client) GetMeasurement(id string) (*Measurement, error) {
-	respBytes, err := c.GetMeasurementRaw(id)
+func (c *client) GetMeasurement(ctx context.Context, id string) (*Measurement, error) {
+	respBytes, err := c.GetMeasurementRaw(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -105,47 +125,66 @@ func (c *client) GetMeasurement(id string) (*Measurement, error) {
 }
 
 // GetMeasurementRaw returns the API response's raw json response
-func (c *client) GetMeasurementRaw(id string) ([]byte, error) {
+//
+// Finished measurements are served from the cache without a network round trip.
+func (c *client) GetMeasurementRaw(ctx context.Context, id string) ([]byte, error) {
+	cached, ok := c.cache.Get(id)
+	if ok && cached.finished {
+		return cached.body, nil
+	}
+	if !ok {
+		body, ok := c.readDiskCache(id)
+		if ok {
+			c.cache.Set(cacheEntry{id: id, body: body, finished: true})
+			return body, nil
+		}
+	}
+
 	// Create a new request
-	req, err := http.NewRequest("GET", c.apiUrl+"/"+id, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.apiUrl+"/"+id, nil)
 	if err != nil {
 		return nil, errors.New("err: failed to create request")
 	}
 
-	req.Header.Set("User-Agent", userAgent())
-	req.Header.Set("Accept-Encoding", "br")
+	c.setHeaders(req)
 
-	etag := c.etags[id]
-	if etag != "" {
-		req.Header.Set("If-None-Match", etag)
+	if cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
 	}
 
 	// Make the request
-	resp, err := c.http.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, errors.New("err: request failed")
 	}
 	defer resp.Body.Close()
 
 	// 404 not found
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, errors.New("err: measurement not found")
+		return nil, &NotFoundError{ID: id}
+	}
+
+	// 429 error
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, newRateLimitError(resp.Header)
 	}
 
-	// 500 error
-	if resp.StatusCode == http.StatusInternalServerError {
-		return nil, errors.New("err: internal server error - please try again later")
+	// 5xx error
+	if resp.StatusCode >= 500 {
+		return nil, &ServerError{StatusCode: resp.StatusCode}
 	}
 
 	// 304 not modified
 	if resp.StatusCode == http.StatusNotModified {
 		// get response bytes from cache
-		respBytes := c.measurements[etag]
-		if respBytes == nil {
+		if cached.body == nil {
 			return nil, errors.New("err: response not found in etags cache")
 		}
 
-		return respBytes, nil
+		return cached.body, nil
 	}
 
 	if resp.StatusCode >= 400 {
@@ -165,13 +204,104 @@ func (c *client) GetMeasurementRaw(id string) ([]byte, error) {
 	}
 
 	// save etag and response to cache
-	etag = resp.Header.Get("ETag")
-	c.etags[id] = etag
-	c.measurements[etag] = respBytes
+	finished := isFinished(respBytes)
+	c.cache.Set(cacheEntry{
+		id:       id,
+		etag:     resp.Header.Get("ETag"),
+		body:     respBytes,
+		finished: finished,
+	})
+	if finished {
+		c.writeDiskCache(id, respBytes)
+	}
 
 	return respBytes, nil
 }
 
+// GetLimits returns the rate limits and credits available to the caller
+func (c *client) GetLimits(ctx context.Context) (*LimitsResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseUrl+"/limits", nil)
+	if err != nil {
+		return nil, errors.New("err: failed to create request")
+	}
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, errors.New("err: request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, newRateLimitError(resp.Header)
+	}
+
+	if resp.StatusCode >= 500 {
+		return nil, &ServerError{StatusCode: resp.StatusCode}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("err: response code %d", resp.StatusCode)
+	}
+
+	var bodyReader io.Reader = resp.Body
+	if resp.Header.Get("Content-Encoding") == "br" {
+		bodyReader = brotli.NewReader(bodyReader)
+	}
+
+	limits := &LimitsResponse{}
+	err = json.NewDecoder(bodyReader).Decode(limits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid limits format returned - please report this bug: %s", err)
+	}
+	return limits, nil
+}
+
+// GetProbes returns the list of online probes
+func (c *client) GetProbes(ctx context.Context) ([]Probe, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseUrl+"/probes", nil)
+	if err != nil {
+		return nil, errors.New("err: failed to create request")
+	}
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, errors.New("err: request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, newRateLimitError(resp.Header)
+	}
+
+	if resp.StatusCode >= 500 {
+		return nil, &ServerError{StatusCode: resp.StatusCode}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("err: response code %d", resp.StatusCode)
+	}
+
+	var bodyReader io.Reader = resp.Body
+	if resp.Header.Get("Content-Encoding") == "br" {
+		bodyReader = brotli.NewReader(bodyReader)
+	}
+
+	probes := []Probe{}
+	err = json.NewDecoder(bodyReader).Decode(&probes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid probes format returned - please report this bug: %s", err)
+	}
+	return probes, nil
+}
+
 func DecodeDNSTimings(timings json.RawMessage) (*DNSTimings, error) {
 	t := &DNSTimings{}
 	err := json.Unmarshal(timings, t)
@@ -181,6 +311,88 @@ func DecodeDNSTimings(timings json.RawMessage) (*DNSTimings, error) {
 	return t, nil
 }
 
+// DecodeDNSAnswers decodes the answers of a dns measurement, returning no answers if there are none
+func DecodeDNSAnswers(answers json.RawMessage) ([]DNSAnswer, error) {
+	a := []DNSAnswer{}
+	if len(answers) == 0 {
+		return a, nil
+	}
+	err := json.Unmarshal(answers, &a)
+	if err != nil {
+		return nil, errors.New("invalid answers format returned (dns)")
+	}
+	return a, nil
+}
+
+// DecodeDNSTraceHops decodes the hops of a dns measurement with the trace option enabled
+func DecodeDNSTraceHops(hops json.RawMessage) ([]DNSTraceHop, error) {
+	h := []DNSTraceHop{}
+	err := json.Unmarshal(hops, &h)
+	if err != nil {
+		return nil, errors.New("invalid hops format returned (dns)")
+	}
+	return h, nil
+}
+
+func DecodeTracerouteHops(hops json.RawMessage) ([]TracerouteHop, error) {
+	h := []TracerouteHop{}
+	err := json.Unmarshal(hops, &h)
+	if err != nil {
+		return nil, errors.New("invalid hops format returned (traceroute)")
+	}
+	return h, nil
+}
+
+func DecodeMTRHops(hops json.RawMessage) ([]MTRHop, error) {
+	h := []MTRHop{}
+	err := json.Unmarshal(hops, &h)
+	if err != nil {
+		return nil, errors.New("invalid hops format returned (mtr)")
+	}
+	return h, nil
+}
+
+// DecodeHTTPHeaders decodes the response headers of an http measurement,
+// the API returns a string for single value headers and an array of strings for repeated headers
+func DecodeHTTPHeaders(headers json.RawMessage) (HTTPHeaders, error) {
+	h := HTTPHeaders{}
+	if len(headers) == 0 {
+		return h, nil
+	}
+	raw := map[string]json.RawMessage{}
+	err := json.Unmarshal(headers, &raw)
+	if err != nil {
+		return nil, errors.New("invalid headers format returned (http)")
+	}
+	for name, value := range raw {
+		var values []string
+		err := json.Unmarshal(value, &values)
+		if err != nil {
+			var v string
+			err = json.Unmarshal(value, &v)
+			if err != nil {
+				return nil, errors.New("invalid headers format returned (http)")
+			}
+			values = []string{v}
+		}
+		h[strings.ToLower(name)] = values
+	}
+	return h, nil
+}
+
+// DecodeHTTPTLS decodes the TLS details of an http measurement, returning nil if TLS was not used
+func DecodeHTTPTLS(tls json.RawMessage) (*HTTPTLS, error) {
+	if len(tls) == 0 || string(tls) == "null" {
+		return nil, nil
+	}
+	t := &HTTPTLS{}
+	err := json.Unmarshal(tls, t)
+	if err != nil {
+		return nil, errors.New("invalid tls format returned (http)")
+	}
+	return t, nil
+}
+
 func DecodeHTTPTimings(timings json.RawMessage) (*HTTPTimings, error) {
 	t := &HTTPTimings{}
 	err := json.Unmarshal(timings, t)
@@ -208,6 +420,74 @@ func DecodePingStats(stats json.RawMessage) (*PingStats, error) {
 	return s, nil
 }
 
+// Sends the request, retrying connection errors and 5xx responses with a jittered exponential backoff
+func (c *client) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.http.Do(req)
+		if attempt > c.maxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+		var reason string
+		if err != nil {
+			reason = err.Error()
+		} else if resp.StatusCode >= 500 {
+			reason = resp.Status
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			return resp, nil
+		}
+
+		delay := c.retryDelay(attempt)
+		c.log("%s %s failed (%s), retrying in %s (retry %d of %d)", req.Method, req.URL.Path, reason, delay, attempt, c.maxRetries)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		// The body was consumed by the previous attempt
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// Returns the delay before the given retry, picked randomly between half and the full exponential backoff
+func (c *client) retryDelay(attempt int) time.Duration {
+	base := c.retryBackoff
+	if base <= 0 {
+		base = API_RETRY_BACKOFF
+	}
+	d := base << (attempt - 1)
+	if d <= 0 || d > API_MAX_RETRY_DELAY {
+		d = API_MAX_RETRY_DELAY
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+func (c *client) log(format string, args ...any) {
+	if c.logf != nil {
+		c.logf(format, args...)
+	}
+}
+
+// Sets the headers shared by all API requests
+func (c *client) setHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", userAgent())
+	req.Header.Set("Accept-Encoding", "br")
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+}
+
 func userAgent() string {
 	return fmt.Sprintf("globalping-cli/v%s (https://github.com/jsdelivr/globalping-cli)", version.Version)
 }
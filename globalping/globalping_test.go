@@ -1,12 +1,16 @@
 package globalping
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/andybalholm/brotli"
 	"github.com/jsdelivr/globalping-cli/version"
@@ -23,6 +27,7 @@ func TestPostAPI(t *testing.T) {
 		"no_probes":  testPostNoProbes,
 		"validation": testPostValidation,
 		"api_error":  testPostInternalError,
+		"rate_limit": testPostRateLimit,
 	} {
 		t.Run(scenario, func(t *testing.T) {
 			fn(t)
@@ -34,14 +39,13 @@ func TestPostAPI(t *testing.T) {
 func testPostValid(t *testing.T) {
 	server := generateServer(`{"id":"abcd","probesCount":1}`)
 	defer server.Close()
-	client := NewClient(server.URL)
+	client := NewClient(Config{APIURL: server.URL})
 
 	opts := &MeasurementCreate{}
-	res, showHelp, err := client.CreateMeasurement(opts)
+	res, err := client.CreateMeasurement(context.Background(), opts)
 
 	assert.Equal(t, "abcd", res.ID)
 	assert.Equal(t, 1, res.ProbesCount)
-	assert.False(t, showHelp)
 	assert.NoError(t, err)
 }
 
@@ -53,12 +57,14 @@ func testPostNoProbes(t *testing.T) {
     }}`, 422)
 	defer server.Close()
 
-	client := NewClient(server.URL)
+	client := NewClient(Config{APIURL: server.URL})
 	opts := &MeasurementCreate{}
-	_, showHelp, err := client.CreateMeasurement(opts)
+	_, err := client.CreateMeasurement(context.Background(), opts)
 
+	noProbesErr := &NoProbesError{}
+	assert.ErrorAs(t, err, &noProbesErr)
+	assert.Equal(t, "No suitable probes found", noProbesErr.Message)
 	assert.EqualError(t, err, "no suitable probes found - please choose a different location")
-	assert.True(t, showHelp)
 }
 
 func testPostValidation(t *testing.T) {
@@ -72,23 +78,21 @@ func testPostValidation(t *testing.T) {
         }
     }}`, 400)
 	defer server.Close()
-	client := NewClient(server.URL)
+	client := NewClient(Config{APIURL: server.URL})
 
 	opts := &MeasurementCreate{}
-	_, showHelp, err := client.CreateMeasurement(opts)
-
-	// Key order is not guaranteed
-	expectedErrV1 := `invalid parameters
+	_, err := client.CreateMeasurement(context.Background(), opts)
+
+	validationErr := &ValidationError{}
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, map[string]string{
+		"measurement": `"measurement" does not match any of the allowed types`,
+		"target":      `"target" does not match any of the allowed types`,
+	}, validationErr.Params)
+	assert.EqualError(t, err, `invalid parameters
  - "measurement" does not match any of the allowed types
  - "target" does not match any of the allowed types
-Please check the help for more information`
-	if err.Error() != expectedErrV1 {
-		assert.EqualError(t, err, `invalid parameters
- - "target" does not match any of the allowed types
- - "measurement" does not match any of the allowed types
 Please check the help for more information`)
-	}
-	assert.True(t, showHelp)
 }
 
 func testPostInternalError(t *testing.T) {
@@ -98,12 +102,62 @@ func testPostInternalError(t *testing.T) {
       "type": "api_error"
     }}`, 500)
 	defer server.Close()
-	client := NewClient(server.URL)
+	client := NewClient(Config{APIURL: server.URL})
 
 	opts := &MeasurementCreate{}
-	_, showHelp, err := client.CreateMeasurement(opts)
+	_, err := client.CreateMeasurement(context.Background(), opts)
+
+	serverErr := &ServerError{}
+	assert.ErrorAs(t, err, &serverErr)
+	assert.Equal(t, 500, serverErr.StatusCode)
 	assert.EqualError(t, err, "internal server error - please try again later")
-	assert.False(t, showHelp)
+}
+
+func testPostRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "500")
+		w.Header().Set("Retry-After", "42")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, err := w.Write([]byte(`{"error":{"message":"Too many requests","type":"rate_limit_exceeded"}}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	client := NewClient(Config{APIURL: server.URL})
+
+	opts := &MeasurementCreate{}
+	_, err := client.CreateMeasurement(context.Background(), opts)
+
+	rateLimitErr := &RateLimitError{}
+	assert.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, &RateLimitError{Limit: 100, Remaining: 0, Reset: 42 * time.Second}, rateLimitErr)
+	assert.EqualError(t, err, "rate limit exceeded - please try again in 42s or authenticate to get higher limits")
+}
+
+func TestGetNotFound(t *testing.T) {
+	server := generateServerError(`{"error":{"message":"Couldn't find the requested measurement.","type":"not_found"}}`, 404)
+	defer server.Close()
+	client := NewClient(Config{APIURL: server.URL})
+
+	_, err := client.GetMeasurement(context.Background(), "abcd")
+
+	notFoundErr := &NotFoundError{}
+	assert.ErrorAs(t, err, &notFoundErr)
+	assert.Equal(t, "abcd", notFoundErr.ID)
+	assert.EqualError(t, err, "measurement abcd not found")
+}
+
+func TestGetServerError(t *testing.T) {
+	server := generateServerError(`Bad Gateway`, 502)
+	defer server.Close()
+	client := NewClient(Config{APIURL: server.URL})
+
+	_, err := client.GetMeasurementRaw(context.Background(), "abcd")
+
+	serverErr := &ServerError{}
+	assert.ErrorAs(t, err, &serverErr)
+	assert.Equal(t, 502, serverErr.StatusCode)
 }
 
 // GetAPI tests
@@ -126,8 +180,8 @@ func TestGetAPI(t *testing.T) {
 func testGetValid(t *testing.T) {
 	server := generateServer(`{"id":"abcd"}`)
 	defer server.Close()
-	client := NewClient(server.URL)
-	res, err := client.GetMeasurement("abcd")
+	client := NewClient(Config{APIURL: server.URL})
+	res, err := client.GetMeasurement(context.Background(), "abcd")
 	if err != nil {
 		t.Error(err)
 	}
@@ -137,8 +191,8 @@ func testGetValid(t *testing.T) {
 func testGetJson(t *testing.T) {
 	server := generateServer(`{"id":"abcd"}`)
 	defer server.Close()
-	client := NewClient(server.URL)
-	res, err := client.GetMeasurementRaw("abcd")
+	client := NewClient(Config{APIURL: server.URL})
+	res, err := client.GetMeasurementRaw(context.Background(), "abcd")
 	if err != nil {
 		t.Error(err)
 	}
@@ -190,9 +244,9 @@ func testGetPing(t *testing.T) {
 		}
 	}]}`)
 	defer server.Close()
-	client := NewClient(server.URL)
+	client := NewClient(Config{APIURL: server.URL})
 
-	res, err := client.GetMeasurement("abcd")
+	res, err := client.GetMeasurement(context.Background(), "abcd")
 	if err != nil {
 		t.Error(err)
 	}
@@ -286,9 +340,9 @@ func testGetTraceroute(t *testing.T) {
 	}}]}`)
 	defer server.Close()
 
-	client := NewClient(server.URL)
+	client := NewClient(Config{APIURL: server.URL})
 
-	res, err := client.GetMeasurement("abcd")
+	res, err := client.GetMeasurement(context.Background(), "abcd")
 	if err != nil {
 		t.Error(err)
 	}
@@ -313,6 +367,22 @@ func testGetTraceroute(t *testing.T) {
 	assert.Equal(t, "TRACEROUTE", res.Results[0].Result.RawOutput)
 	assert.Equal(t, "1.1.1.1", res.Results[0].Result.ResolvedAddress)
 	assert.Equal(t, "1.1.1.1", res.Results[0].Result.ResolvedHostname)
+
+	// Test hops
+	hops, err := DecodeTracerouteHops(res.Results[0].Result.HopsRaw)
+	assert.NoError(t, err)
+	assert.Equal(t, []TracerouteHop{
+		{
+			ResolvedAddress:  "54.37.244.252",
+			ResolvedHostname: "54.37.244.252",
+			Timings:          []HopTiming{{RTT: 0.408}, {RTT: 0.502}},
+		},
+		{
+			ResolvedAddress:  "93.123.11.62",
+			ResolvedHostname: "93.123.11.62",
+			Timings:          []HopTiming{{RTT: 0.507}, {RTT: 0.524}},
+		},
+	}, hops)
 }
 
 func testGetDns(t *testing.T) {
@@ -362,9 +432,9 @@ func testGetDns(t *testing.T) {
 		}
 	}]}`)
 	defer server.Close()
-	client := NewClient(server.URL)
+	client := NewClient(Config{APIURL: server.URL})
 
-	res, err := client.GetMeasurement("abcd")
+	res, err := client.GetMeasurement(context.Background(), "abcd")
 	if err != nil {
 		t.Error(err)
 	}
@@ -393,6 +463,46 @@ func testGetDns(t *testing.T) {
 	// Test timings
 	timings, _ := DecodeDNSTimings(res.Results[0].Result.TimingsRaw)
 	assert.Equal(t, float64(15), timings.Total)
+
+	// Test answers
+	assert.Equal(t, 0, res.Results[0].Result.StatusCode)
+	assert.Equal(t, "NOERROR", res.Results[0].Result.StatusCodeName)
+	assert.Equal(t, "185.31.172.240", res.Results[0].Result.Resolver)
+	answers, err := DecodeDNSAnswers(res.Results[0].Result.AnswersRaw)
+	assert.NoError(t, err)
+	assert.Equal(t, []DNSAnswer{{Name: "jsdelivr.com.", Type: "A", TTL: 30, Class: "IN", Value: "92.223.84.84"}}, answers)
+}
+
+func TestDecodeDNSTraceHops(t *testing.T) {
+	hops, err := DecodeDNSTraceHops(json.RawMessage(`[
+		{
+			"resolver": "a.root-servers.net",
+			"answers": [{"name": "com.", "type": "NS", "ttl": 172800, "class": "IN", "value": "a.gtld-servers.net."}],
+			"timings": {"total": 12}
+		},
+		{
+			"resolver": "ns1.jsdelivr.com",
+			"answers": [{"name": "jsdelivr.com.", "type": "A", "ttl": 30, "class": "IN", "value": "92.223.84.84"}],
+			"timings": {"total": 8}
+		}
+	]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []DNSTraceHop{
+		{
+			Resolver: "a.root-servers.net",
+			Answers:  []DNSAnswer{{Name: "com.", Type: "NS", TTL: 172800, Class: "IN", Value: "a.gtld-servers.net."}},
+			Timings:  DNSTimings{Total: 12},
+		},
+		{
+			Resolver: "ns1.jsdelivr.com",
+			Answers:  []DNSAnswer{{Name: "jsdelivr.com.", Type: "A", TTL: 30, Class: "IN", Value: "92.223.84.84"}},
+			Timings:  DNSTimings{Total: 8},
+		},
+	}, hops)
+
+	answers, err := DecodeDNSAnswers(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []DNSAnswer{}, answers)
 }
 
 func testGetMtr(t *testing.T) {
@@ -484,9 +594,9 @@ func testGetMtr(t *testing.T) {
 		}
 	}]}`)
 	defer server.Close()
-	client := NewClient(server.URL)
+	client := NewClient(Config{APIURL: server.URL})
 
-	res, err := client.GetMeasurement("abcd")
+	res, err := client.GetMeasurement(context.Background(), "abcd")
 	if err != nil {
 		t.Error(err)
 	}
@@ -511,6 +621,27 @@ func testGetMtr(t *testing.T) {
 	assert.Equal(t, "MTR", res.Results[0].Result.RawOutput)
 	assert.Equal(t, StatusFinished, res.Results[0].Result.Status)
 	assert.IsType(t, json.RawMessage{}, res.Results[0].Result.TimingsRaw)
+
+	// Test hops
+	hops, err := DecodeMTRHops(res.Results[0].Result.HopsRaw)
+	assert.NoError(t, err)
+	assert.Equal(t, []MTRHop{
+		{
+			ResolvedAddress:  "172.19.66.225",
+			ResolvedHostname: "172.19.66.225",
+			ASN:              []int{},
+			Stats:            MTRStats{Min: 0.176, Avg: 0.2, Max: 0.226, JMax: 0.2, JAvg: 0.1, Total: 3, Rcv: 3},
+			Timings:          []HopTiming{{RTT: 0.176}, {RTT: 0.216}, {RTT: 0.226}},
+		},
+		{
+			ResolvedAddress:  "92.223.84.84",
+			ResolvedHostname: "92.223.84.84",
+			ASN:              []int{199524},
+			Stats:            MTRStats{Min: 0.894, Avg: 0.9, Max: 0.894, JMin: 0.9, JAvg: 0.9, JMax: 0.9, Total: 1, Rcv: 1},
+			Timings:          []HopTiming{{RTT: 0.894}},
+			Duplicate:        true,
+		},
+	}, hops)
 }
 
 func testGetHttp(t *testing.T) {
@@ -589,9 +720,9 @@ func testGetHttp(t *testing.T) {
 		}
 	}]}`)
 	defer server.Close()
-	client := NewClient(server.URL)
+	client := NewClient(Config{APIURL: server.URL})
 
-	res, err := client.GetMeasurement("abcd")
+	res, err := client.GetMeasurement(context.Background(), "abcd")
 	if err != nil {
 		t.Error(err)
 	}
@@ -625,6 +756,52 @@ func testGetHttp(t *testing.T) {
 	assert.Equal(t, 24, timings.DNS)
 	assert.Equal(t, 70, timings.TLS)
 	assert.Equal(t, 19, timings.TCP)
+
+	// Test response
+	assert.Equal(t, 301, res.Results[0].Result.StatusCode)
+	assert.Equal(t, "Moved Permanently", res.Results[0].Result.StatusCodeName)
+	assert.Equal(t, "5.101.222.14", res.Results[0].Result.ResolvedAddress)
+
+	headers, err := DecodeHTTPHeaders(res.Results[0].Result.HeadersRaw)
+	assert.NoError(t, err)
+	assert.Equal(t, 13, len(headers))
+	assert.Equal(t, "nginx", headers.Get("Server"))
+	assert.Equal(t, []string{"MISS, MISS"}, headers["cache"])
+
+	tls, err := DecodeHTTPTLS(res.Results[0].Result.TLSRaw)
+	assert.NoError(t, err)
+	assert.Equal(t, &HTTPTLS{
+		Authorized: true,
+		CreatedAt:  time.Date(2023, 2, 18, 0, 0, 0, 0, time.UTC),
+		ExpiresAt:  time.Date(2024, 2, 18, 23, 59, 59, 0, time.UTC),
+		Subject: HTTPTLSSubject{
+			CommonName: "jsdelivr.com",
+			AltNames:   "DNS:jsdelivr.com, DNS:data.jsdelivr.com, DNS:www.jsdelivr.com",
+		},
+		Issuer: HTTPTLSIssuer{
+			Country:      "GB",
+			Organization: "Sectigo Limited",
+			CommonName:   "Sectigo RSA Domain Validation Secure Server CA",
+		},
+	}, tls)
+}
+
+func TestDecodeHTTPHeaders_Multiple(t *testing.T) {
+	headers, err := DecodeHTTPHeaders(json.RawMessage(`{"Set-Cookie":["a=1","b=2"],"server":"nginx"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, HTTPHeaders{
+		"set-cookie": {"a=1", "b=2"},
+		"server":     {"nginx"},
+	}, headers)
+	assert.Equal(t, "a=1", headers.Get("Set-Cookie"))
+	assert.Equal(t, "", headers.Get("x-missing"))
+
+	_, err = DecodeHTTPHeaders(json.RawMessage(`{"server":1}`))
+	assert.EqualError(t, err, "invalid headers format returned (http)")
+
+	tls, err := DecodeHTTPTLS(json.RawMessage(`null`))
+	assert.NoError(t, err)
+	assert.Nil(t, tls)
 }
 
 func TestFetchWithEtag(t *testing.T) {
@@ -663,22 +840,22 @@ func TestFetchWithEtag(t *testing.T) {
 		assert.NoError(t, err)
 	}))
 
-	client := NewClient(s.URL)
+	client := NewClient(Config{APIURL: s.URL})
 
 	// first request for id1
-	m, err := client.GetMeasurement(id1)
+	m, err := client.GetMeasurement(context.Background(), id1)
 	assert.NoError(t, err)
 
 	assert.Equal(t, id1, m.ID)
 
 	// first request for id1
-	m, err = client.GetMeasurement(id2)
+	m, err = client.GetMeasurement(context.Background(), id2)
 	assert.NoError(t, err)
 
 	assert.Equal(t, id2, m.ID)
 
 	// second request for id1
-	m, err = client.GetMeasurement(id2)
+	m, err = client.GetMeasurement(context.Background(), id2)
 	assert.NoError(t, err)
 
 	assert.Equal(t, id2, m.ID)
@@ -687,6 +864,58 @@ func TestFetchWithEtag(t *testing.T) {
 	assert.Equal(t, 2, cacheMissCount)
 }
 
+func TestFetchFinishedFromCache(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, err := w.Write([]byte(`{"id":"abcd","status":"finished"}`))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+
+	client := NewClient(Config{APIURL: s.URL})
+	for i := 0; i < 2; i++ {
+		b, err := client.GetMeasurementRaw(context.Background(), "abcd")
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"abcd","status":"finished"}`, string(b))
+	}
+	assert.Equal(t, 1, requests)
+}
+
+func TestFetchWithDiskCache(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		status := "in-progress"
+		if requests > 1 {
+			status = "finished"
+		}
+		_, err := w.Write([]byte(`{"id":"abcd","status":"` + status + `"}`))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+
+	dir := t.TempDir()
+
+	client := NewClient(Config{APIURL: s.URL, CacheDir: dir})
+	b, err := client.GetMeasurementRaw(context.Background(), "abcd")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"abcd","status":"in-progress"}`, string(b))
+	assert.NoFileExists(t, dir+"/abcd.json")
+
+	b, err = client.GetMeasurementRaw(context.Background(), "abcd")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"abcd","status":"finished"}`, string(b))
+	assert.FileExists(t, dir+"/abcd.json")
+
+	// A new client reads the finished measurement from disk
+	client = NewClient(Config{APIURL: s.URL, CacheDir: dir})
+	b, err = client.GetMeasurementRaw(context.Background(), "abcd")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"abcd","status":"finished"}`, string(b))
+	assert.Equal(t, 2, requests)
+}
+
 func TestFetchWithBrotli(t *testing.T) {
 	id := "123abc"
 
@@ -709,14 +938,237 @@ func TestFetchWithBrotli(t *testing.T) {
 		assert.NoError(t, err)
 	}))
 
-	client := NewClient(s.URL)
+	client := NewClient(Config{APIURL: s.URL})
 
-	m, err := client.GetMeasurement(id)
+	m, err := client.GetMeasurement(context.Background(), id)
 	assert.NoError(t, err)
 
 	assert.Equal(t, id, m.ID)
 }
 
+func TestGetCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer s.Close()
+
+	client := NewClient(Config{APIURL: s.URL})
+	_, err := client.GetMeasurement(ctx, "abcd")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRetryGet(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, err := w.Write([]byte(`{"id":"abcd"}`))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+
+	logs := []string{}
+	client := NewClient(Config{
+		APIURL:       s.URL + "/measurements",
+		MaxRetries:   3,
+		RetryBackoff: time.Millisecond,
+		Logf: func(format string, args ...any) {
+			logs = append(logs, fmt.Sprintf(format, args...))
+		},
+	})
+	res, err := client.GetMeasurement(context.Background(), "abcd")
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd", res.ID)
+	assert.Equal(t, 3, requests)
+	assert.Len(t, logs, 2)
+	assert.True(t, strings.HasPrefix(logs[0], "GET /measurements/abcd failed (502 Bad Gateway), retrying in "))
+	assert.True(t, strings.HasSuffix(logs[1], "(retry 2 of 3)"))
+}
+
+func TestRetryGetExhausted(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer s.Close()
+
+	client := NewClient(Config{APIURL: s.URL, MaxRetries: 2, RetryBackoff: time.Millisecond})
+	_, err := client.GetMeasurementRaw(context.Background(), "abcd")
+
+	serverErr := &ServerError{}
+	assert.ErrorAs(t, err, &serverErr)
+	assert.Equal(t, 503, serverErr.StatusCode)
+	assert.Equal(t, 3, requests)
+}
+
+func TestRetryPost(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), `"target":"jsdelivr.com"`)
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, err = w.Write([]byte(`{"id":"abcd","probesCount":1}`))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+
+	client := NewClient(Config{APIURL: s.URL, MaxRetries: 1, RetryBackoff: time.Millisecond})
+	res, err := client.CreateMeasurement(context.Background(), &MeasurementCreate{Type: "ping", Target: "jsdelivr.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd", res.ID)
+	assert.Equal(t, 2, requests)
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer s.Close()
+
+	client := NewClient(Config{APIURL: s.URL, MaxRetries: 3, RetryBackoff: time.Millisecond})
+	_, err := client.GetMeasurementRaw(context.Background(), "abcd")
+
+	notFoundErr := &NotFoundError{}
+	assert.ErrorAs(t, err, &notFoundErr)
+	assert.Equal(t, 1, requests)
+}
+
+func TestRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		cancel()
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer s.Close()
+
+	client := NewClient(Config{APIURL: s.URL, MaxRetries: 3, RetryBackoff: time.Hour})
+	_, err := client.GetMeasurementRaw(ctx, "abcd")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, requests)
+}
+
+func TestGetLimits(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/limits", r.URL.Path)
+		_, err := w.Write([]byte(`{
+	"rateLimit": {
+		"measurements": {
+			"create": {"type": "user", "limit": 500, "remaining": 350, "reset": 600}
+		}
+	},
+	"credits": {"remaining": 1000}
+}`))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+
+	// The base url of the limits endpoint is derived from the measurements endpoint
+	client := NewClient(Config{APIURL: s.URL + "/measurements"})
+	limits, err := client.GetLimits(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &LimitsResponse{
+		RateLimits: RateLimits{
+			Measurements: MeasurementsLimits{
+				Create: MeasurementsCreateLimits{
+					Type:      CreateLimitTypeUser,
+					Limit:     500,
+					Remaining: 350,
+					Reset:     600,
+				},
+			},
+		},
+		Credits: &CreditLimits{Remaining: 1000},
+	}, limits)
+}
+
+func TestGetProbes(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/probes", r.URL.Path)
+		_, err := w.Write([]byte(`[{
+	"version": "0.28.0",
+	"location": {
+		"continent": "EU",
+		"region": "Western Europe",
+		"country": "DE",
+		"state": null,
+		"city": "Berlin",
+		"asn": 3320,
+		"latitude": 52.52,
+		"longitude": 13.41,
+		"network": "Deutsche Telekom AG"
+	},
+	"tags": ["eyeball-network"],
+	"resolvers": ["private"]
+}]`))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+
+	client := NewClient(Config{APIURL: s.URL})
+	probes, err := client.GetProbes(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Probe{{
+		Version: "0.28.0",
+		Location: ProbeDetails{
+			Continent: "EU",
+			Region:    "Western Europe",
+			Country:   "DE",
+			City:      "Berlin",
+			ASN:       3320,
+			Network:   "Deutsche Telekom AG",
+		},
+		Tags:      []string{"eyeball-network"},
+		Resolvers: []string{"private"},
+	}}, probes)
+}
+
+func TestAuthorizationHeader(t *testing.T) {
+	authHeaders := []string{}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			_, err := w.Write([]byte(`{"id":"abcd","probesCount":1}`))
+			assert.NoError(t, err)
+			return
+		}
+		_, err := w.Write([]byte(`{"id":"abcd"}`))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+
+	client := NewClient(Config{APIURL: s.URL, AuthToken: "tok3n"})
+
+	_, err := client.CreateMeasurement(context.Background(), &MeasurementCreate{})
+	assert.NoError(t, err)
+
+	_, err = client.GetMeasurementRaw(context.Background(), "abcd")
+	assert.NoError(t, err)
+
+	client = NewClient(Config{APIURL: s.URL})
+	_, err = client.GetMeasurementRaw(context.Background(), "abcd")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Bearer tok3n", "Bearer tok3n", ""}, authHeaders)
+}
+
 func TestUserAgent(t *testing.T) {
 	version.Version = "x.y.z"
 	assert.Equal(t, "globalping-cli/vx.y.z (https://github.com/jsdelivr/globalping-cli)", userAgent())
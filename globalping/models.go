@@ -1,6 +1,10 @@
 package globalping
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
 
 // Docs: https://www.jsdelivr.com/docs/api.globalping.io
 
@@ -63,6 +67,14 @@ type ProbeDetails struct {
 	Tags      []string `json:"tags,omitempty"`
 }
 
+// Probe is an online probe as returned by the probes endpoint
+type Probe struct {
+	Version   string       `json:"version"`
+	Location  ProbeDetails `json:"location"`
+	Tags      []string     `json:"tags"`
+	Resolvers []string     `json:"resolvers"`
+}
+
 type MeasurementStatus string
 
 const (
@@ -79,8 +91,15 @@ type ProbeResult struct {
 	RawBody          string            `json:"rawBody"`
 	ResolvedAddress  string            `json:"resolvedAddress"`
 	ResolvedHostname string            `json:"resolvedHostname"`
+	StatusCode       int               `json:"statusCode,omitempty"`
+	StatusCodeName   string            `json:"statusCodeName,omitempty"`
+	Resolver         string            `json:"resolver,omitempty"`
 	StatsRaw         json.RawMessage   `json:"stats,omitempty"`
 	TimingsRaw       json.RawMessage   `json:"timings,omitempty"`
+	AnswersRaw       json.RawMessage   `json:"answers,omitempty"`
+	HopsRaw          json.RawMessage   `json:"hops,omitempty"`
+	HeadersRaw       json.RawMessage   `json:"headers,omitempty"`
+	TLSRaw           json.RawMessage   `json:"tls,omitempty"`
 }
 
 type PingStats struct {
@@ -103,6 +122,94 @@ type DNSTimings struct {
 	Total float64 `json:"total"` // The total query time in milliseconds.
 }
 
+type DNSAnswer struct {
+	Name  string `json:"name"`  // The record domain name.
+	Type  string `json:"type"`  // The record type.
+	TTL   int    `json:"ttl"`   // The record time-to-live value in seconds.
+	Class string `json:"class"` // The record class.
+	Value string `json:"value"` // The record value.
+}
+
+// DNSTraceHop is a resolver queried by a dns measurement with the trace option enabled
+type DNSTraceHop struct {
+	Resolver string      `json:"resolver"` // The hostname or IP of the resolver that answered the query.
+	Answers  []DNSAnswer `json:"answers"`  // The answers returned by the resolver.
+	Timings  DNSTimings  `json:"timings"`  // The query timings.
+}
+
+type HopTiming struct {
+	RTT float64 `json:"rtt"` // The round-trip time for this packet.
+}
+
+// TracerouteHop is a hop of a traceroute measurement, the hop number is its position starting at 1
+type TracerouteHop struct {
+	ResolvedAddress  string      `json:"resolvedAddress"`  // The resolved IP address of the hop, empty if the hop did not reply.
+	ResolvedHostname string      `json:"resolvedHostname"` // The resolved hostname of the hop.
+	Timings          []HopTiming `json:"timings"`          // The round-trip times of the packets sent to the hop.
+}
+
+type MTRStats struct {
+	Min   float64 `json:"min"`   // The lowest rtt value.
+	Avg   float64 `json:"avg"`   // The average rtt value.
+	Max   float64 `json:"max"`   // The highest rtt value.
+	StDev float64 `json:"stDev"` // The standard deviation of the rtt values.
+	JMin  float64 `json:"jMin"`  // The lowest jitter value.
+	JAvg  float64 `json:"jAvg"`  // The average jitter value.
+	JMax  float64 `json:"jMax"`  // The highest jitter value.
+	Total int     `json:"total"` // The number of sent packets.
+	Rcv   int     `json:"rcv"`   // The number of received packets.
+	Drop  int     `json:"drop"`  // The number of dropped packets (total - rcv).
+	Loss  float64 `json:"loss"`  // The percentage of dropped packets.
+}
+
+// MTRHop is a hop of an mtr measurement, the hop number is its position starting at 1
+type MTRHop struct {
+	ResolvedAddress  string      `json:"resolvedAddress"`  // The resolved IP address of the hop, empty if the hop did not reply.
+	ResolvedHostname string      `json:"resolvedHostname"` // The resolved hostname of the hop.
+	ASN              []int       `json:"asn"`              // The autonomous system numbers the hop belongs to.
+	Stats            MTRStats    `json:"stats"`            // The summary of the packets sent to the hop.
+	Timings          []HopTiming `json:"timings"`          // The round-trip times of the packets sent to the hop.
+	Duplicate        bool        `json:"duplicate"`        // Whether the hop address was already seen on a previous hop.
+}
+
+// HTTPHeaders are the response headers of an http measurement, keyed by lowercase header name
+type HTTPHeaders map[string][]string
+
+// Get returns the first value of the header, or an empty string if the header is not set
+func (h HTTPHeaders) Get(name string) string {
+	values := h[strings.ToLower(name)]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+type HTTPTLSSubject struct {
+	CommonName string `json:"CN"`  // The subject common name.
+	AltNames   string `json:"alt"` // The subject alternative names, e.g. "DNS:jsdelivr.com, DNS:www.jsdelivr.com".
+}
+
+type HTTPTLSIssuer struct {
+	Country      string `json:"C"`  // The issuer country.
+	Organization string `json:"O"`  // The issuer organization.
+	CommonName   string `json:"CN"` // The issuer common name.
+}
+
+type HTTPTLS struct {
+	Authorized     bool           `json:"authorized"`     // Whether the certificate is trusted by the probe.
+	Error          string         `json:"error"`          // The reason the certificate is not trusted.
+	Protocol       string         `json:"protocol"`       // The negotiated TLS protocol, e.g. "TLSv1.3".
+	CipherName     string         `json:"cipherName"`     // The negotiated cipher suite.
+	CreatedAt      time.Time      `json:"createdAt"`      // The start of the certificate validity period.
+	ExpiresAt      time.Time      `json:"expiresAt"`      // The end of the certificate validity period.
+	Subject        HTTPTLSSubject `json:"subject"`        // The certificate subject.
+	Issuer         HTTPTLSIssuer  `json:"issuer"`         // The certificate issuer.
+	KeyType        string         `json:"keyType"`        // The type of the public key, e.g. "RSA" or "EC".
+	KeyBits        int            `json:"keyBits"`        // The size of the public key in bits.
+	SerialNumber   string         `json:"serialNumber"`   // The certificate serial number.
+	Fingerprint256 string         `json:"fingerprint256"` // The SHA-256 fingerprint of the certificate.
+}
+
 type HTTPTimings struct {
 	Total     int `json:"total"`     // The total HTTP request time
 	DNS       int `json:"dns"`       // The time required to perform the DNS lookup.
@@ -127,3 +234,34 @@ type Measurement struct {
 	ProbesCount int                `json:"probesCount"`
 	Results     []ProbeMeasurement `json:"results"`
 }
+
+type CreateLimitType string
+
+const (
+	CreateLimitTypeIP   CreateLimitType = "ip"
+	CreateLimitTypeUser CreateLimitType = "user"
+)
+
+type MeasurementsCreateLimits struct {
+	Type      CreateLimitType `json:"type"`      // Whether the limit applies to the IP address or to the authenticated user.
+	Limit     int64           `json:"limit"`     // The number of measurements that can be created per period.
+	Remaining int64           `json:"remaining"` // The number of measurements that can still be created in the current period.
+	Reset     int64           `json:"reset"`     // The number of seconds until the limit resets.
+}
+
+type MeasurementsLimits struct {
+	Create MeasurementsCreateLimits `json:"create"`
+}
+
+type RateLimits struct {
+	Measurements MeasurementsLimits `json:"measurements"`
+}
+
+type CreditLimits struct {
+	Remaining int64 `json:"remaining"` // The number of credits available to create measurements above the rate limits.
+}
+
+type LimitsResponse struct {
+	RateLimits RateLimits    `json:"rateLimit"`
+	Credits    *CreditLimits `json:"credits,omitempty"` // Only returned for authenticated requests.
+}
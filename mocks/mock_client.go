@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	globalping "github.com/jsdelivr/globalping-cli/globalping"
@@ -40,47 +41,76 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // CreateMeasurement mocks base method.
-func (m *MockClient) CreateMeasurement(measurement *globalping.MeasurementCreate) (*globalping.MeasurementCreateResponse, bool, error) {
+func (m *MockClient) CreateMeasurement(ctx context.Context, measurement *globalping.MeasurementCreate) (*globalping.MeasurementCreateResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMeasurement", measurement)
+	ret := m.ctrl.Call(m, "CreateMeasurement", ctx, measurement)
 	ret0, _ := ret[0].(*globalping.MeasurementCreateResponse)
-	ret1, _ := ret[1].(bool)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CreateMeasurement indicates an expected call of CreateMeasurement.
-func (mr *MockClientMockRecorder) CreateMeasurement(measurement any) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateMeasurement(ctx, measurement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeasurement", reflect.TypeOf((*MockClient)(nil).CreateMeasurement), ctx, measurement)
+}
+
+// GetLimits mocks base method.
+func (m *MockClient) GetLimits(ctx context.Context) (*globalping.LimitsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimits", ctx)
+	ret0, _ := ret[0].(*globalping.LimitsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimits indicates an expected call of GetLimits.
+func (mr *MockClientMockRecorder) GetLimits(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimits", reflect.TypeOf((*MockClient)(nil).GetLimits), ctx)
+}
+
+// GetProbes mocks base method.
+func (m *MockClient) GetProbes(ctx context.Context) ([]globalping.Probe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProbes", ctx)
+	ret0, _ := ret[0].([]globalping.Probe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProbes indicates an expected call of GetProbes.
+func (mr *MockClientMockRecorder) GetProbes(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeasurement", reflect.TypeOf((*MockClient)(nil).CreateMeasurement), measurement)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProbes", reflect.TypeOf((*MockClient)(nil).GetProbes), ctx)
 }
 
 // GetMeasurement mocks base method.
-func (m *MockClient) GetMeasurement(id string) (*globalping.Measurement, error) {
+func (m *MockClient) GetMeasurement(ctx context.Context, id string) (*globalping.Measurement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMeasurement", id)
+	ret := m.ctrl.Call(m, "GetMeasurement", ctx, id)
 	ret0, _ := ret[0].(*globalping.Measurement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMeasurement indicates an expected call of GetMeasurement.
-func (mr *MockClientMockRecorder) GetMeasurement(id any) *gomock.Call {
+func (mr *MockClientMockRecorder) GetMeasurement(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeasurement", reflect.TypeOf((*MockClient)(nil).GetMeasurement), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeasurement", reflect.TypeOf((*MockClient)(nil).GetMeasurement), ctx, id)
 }
 
 // GetMeasurementRaw mocks base method.
-func (m *MockClient) GetMeasurementRaw(id string) ([]byte, error) {
+func (m *MockClient) GetMeasurementRaw(ctx context.Context, id string) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMeasurementRaw", id)
+	ret := m.ctrl.Call(m, "GetMeasurementRaw", ctx, id)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMeasurementRaw indicates an expected call of GetMeasurementRaw.
-func (mr *MockClientMockRecorder) GetMeasurementRaw(id any) *gomock.Call {
+func (mr *MockClientMockRecorder) GetMeasurementRaw(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeasurementRaw", reflect.TypeOf((*MockClient)(nil).GetMeasurementRaw), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeasurementRaw", reflect.TypeOf((*MockClient)(nil).GetMeasurementRaw), ctx, id)
 }
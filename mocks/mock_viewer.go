@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	globalping "github.com/jsdelivr/globalping-cli/globalping"
@@ -40,31 +41,31 @@ func (m *MockViewer) EXPECT() *MockViewerMockRecorder {
 }
 
 // Output mocks base method.
-func (m_2 *MockViewer) Output(id string, m *globalping.MeasurementCreate) error {
+func (m_2 *MockViewer) Output(ctx context.Context, id string, m *globalping.MeasurementCreate) error {
 	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "Output", id, m)
+	ret := m_2.ctrl.Call(m_2, "Output", ctx, id, m)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Output indicates an expected call of Output.
-func (mr *MockViewerMockRecorder) Output(id, m any) *gomock.Call {
+func (mr *MockViewerMockRecorder) Output(ctx, id, m any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Output", reflect.TypeOf((*MockViewer)(nil).Output), id, m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Output", reflect.TypeOf((*MockViewer)(nil).Output), ctx, id, m)
 }
 
 // OutputInfinite mocks base method.
-func (m_2 *MockViewer) OutputInfinite(m *globalping.Measurement) error {
+func (m_2 *MockViewer) OutputInfinite(ctx context.Context, m *globalping.Measurement) error {
 	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "OutputInfinite", m)
+	ret := m_2.ctrl.Call(m_2, "OutputInfinite", ctx, m)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // OutputInfinite indicates an expected call of OutputInfinite.
-func (mr *MockViewerMockRecorder) OutputInfinite(m any) *gomock.Call {
+func (mr *MockViewerMockRecorder) OutputInfinite(ctx, m any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutputInfinite", reflect.TypeOf((*MockViewer)(nil).OutputInfinite), m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutputInfinite", reflect.TypeOf((*MockViewer)(nil).OutputInfinite), ctx, m)
 }
 
 // OutputSummary mocks base method.
@@ -1,6 +1,9 @@
 package utils
 
-import _time "time"
+import (
+	"context"
+	_time "time"
+)
 
 type Time interface {
 	Now() _time.Time
@@ -15,3 +18,19 @@ func NewTime() Time {
 func (d *time) Now() _time.Time {
 	return _time.Now()
 }
+
+// Sleep pauses the current goroutine for at least the duration d, or until the context is done.
+// It returns the context error if the context is done before the duration elapses.
+func Sleep(ctx context.Context, d _time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := _time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
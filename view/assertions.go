@@ -0,0 +1,201 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+var ErrAssertionFailed = errors.New("assertion failed")
+
+// Thresholds checked against the result of every probe once the measurement is finished, unset values are not checked
+type Assertions struct {
+	MaxLoss         *float64      // Maximum packet loss, in percent
+	MaxAvgRTT       time.Duration // Maximum average RTT, of the last hop for mtr
+	MaxHTTPTotal    time.Duration // Maximum total time of the HTTP request
+	ExpectStatus    string        // Expected HTTP status code or DNS status code name
+	ExpectDNSAnswer string        // Value expected among the DNS answers
+}
+
+func (a *Assertions) IsSet() bool {
+	return a.MaxLoss != nil || a.MaxAvgRTT > 0 || a.MaxHTTPTotal > 0 || a.ExpectStatus != "" || a.ExpectDNSAnswer != ""
+}
+
+type assertionResult struct {
+	Name     string // The flag the assertion was set with
+	Actual   string
+	Expected string
+	Passed   bool
+}
+
+func (r *assertionResult) String() string {
+	status := "PASS"
+	if !r.Passed {
+		status = "FAIL"
+	}
+	return fmt.Sprintf("%s %s: %s (expected %s)", status, r.Name, r.Actual, r.Expected)
+}
+
+// Outputs a pass/fail report of the assertions for every probe and returns ErrAssertionFailed if any of them failed.
+// The report is printed to stderr if the results are output in a machine-readable format.
+func (v *viewer) OutputAssertions(data *globalping.Measurement) error {
+	w := v.printer.OutWriter
+	if v.ctx.ToJSON || v.ctx.Format != "" {
+		w = v.printer.ErrWriter
+	}
+
+	passed, failed := 0, 0
+	for i := range data.Results {
+		result := &data.Results[i]
+		results, err := v.checkAssertions(&result.Result)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, v.getProbeInfo(result))
+		for j := range results {
+			line := results[j].String()
+			if results[j].Passed {
+				passed++
+			} else {
+				failed++
+				line = v.highlight(line)
+			}
+			fmt.Fprintln(w, line)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "Assertions: %d passed, %d failed\n", passed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d checks failed", ErrAssertionFailed, failed, passed+failed)
+	}
+	return nil
+}
+
+// Checks the assertions against the result of a probe. Unfinished results fail a single status check.
+func (v *viewer) checkAssertions(result *globalping.ProbeResult) ([]assertionResult, error) {
+	if result.Status != globalping.StatusFinished {
+		return []assertionResult{{
+			Name:     "status",
+			Actual:   string(result.Status),
+			Expected: string(globalping.StatusFinished),
+		}}, nil
+	}
+
+	a := &v.ctx.Assertions
+	results := []assertionResult{}
+	switch v.ctx.Cmd {
+	case "ping", "mtr":
+		var (
+			loss float64
+			avg  float64
+			rcv  int
+		)
+		if v.ctx.Cmd == "ping" {
+			stats, err := globalping.DecodePingStats(result.StatsRaw)
+			if err != nil {
+				return nil, err
+			}
+			loss, avg, rcv = stats.Loss, stats.Avg, stats.Rcv
+		} else {
+			hops, err := globalping.DecodeMTRHops(result.HopsRaw)
+			if err != nil {
+				return nil, err
+			}
+			if len(hops) > 0 {
+				last := &hops[len(hops)-1]
+				loss, avg, rcv = last.Stats.Loss, last.Stats.Avg, last.Stats.Rcv
+			} else {
+				loss = 100
+			}
+		}
+		if a.MaxLoss != nil {
+			results = append(results, assertionResult{
+				Name:     "max-loss",
+				Actual:   formatFloat(loss) + "%",
+				Expected: "<= " + formatFloat(*a.MaxLoss) + "%",
+				Passed:   loss <= *a.MaxLoss,
+			})
+		}
+		if a.MaxAvgRTT > 0 {
+			r := assertionResult{
+				Name:     "max-avg-rtt",
+				Actual:   "no reply",
+				Expected: "<= " + formatMilliseconds(a.MaxAvgRTT),
+			}
+			if rcv > 0 {
+				r.Actual = formatFloat(avg) + " ms"
+				r.Passed = avg <= durationToMilliseconds(a.MaxAvgRTT)
+			}
+			results = append(results, r)
+		}
+	case "dns":
+		hops, err := decodeDNSHops(result)
+		if err != nil {
+			return nil, err
+		}
+		if a.ExpectStatus != "" {
+			results = append(results, assertionResult{
+				Name:     "expect-status",
+				Actual:   result.StatusCodeName,
+				Expected: a.ExpectStatus,
+				Passed:   strings.EqualFold(result.StatusCodeName, a.ExpectStatus),
+			})
+		}
+		if a.ExpectDNSAnswer != "" {
+			r := assertionResult{
+				Name:     "expect-dns-answer",
+				Actual:   "no answers",
+				Expected: a.ExpectDNSAnswer,
+			}
+			if len(hops) > 0 && len(hops[len(hops)-1].Answers) > 0 {
+				answers := hops[len(hops)-1].Answers
+				values := make([]string, len(answers))
+				for i := range answers {
+					values[i] = answers[i].Value
+					if strings.EqualFold(strings.TrimSuffix(answers[i].Value, "."), strings.TrimSuffix(a.ExpectDNSAnswer, ".")) {
+						r.Passed = true
+					}
+				}
+				r.Actual = strings.Join(values, ", ")
+			}
+			results = append(results, r)
+		}
+	case "http":
+		if a.ExpectStatus != "" {
+			actual := strconv.Itoa(result.StatusCode)
+			results = append(results, assertionResult{
+				Name:     "expect-status",
+				Actual:   actual,
+				Expected: a.ExpectStatus,
+				Passed:   actual == a.ExpectStatus,
+			})
+		}
+		if a.MaxHTTPTotal > 0 {
+			timings, err := globalping.DecodeHTTPTimings(result.TimingsRaw)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, assertionResult{
+				Name:     "max-http-total",
+				Actual:   strconv.Itoa(timings.Total) + " ms",
+				Expected: "<= " + formatMilliseconds(a.MaxHTTPTotal),
+				Passed:   float64(timings.Total) <= durationToMilliseconds(a.MaxHTTPTotal),
+			})
+		}
+	default:
+		return nil, errors.New("unexpected command for assertions: " + v.ctx.Cmd)
+	}
+	return results, nil
+}
+
+func durationToMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func formatMilliseconds(d time.Duration) string {
+	return formatFloat(durationToMilliseconds(d)) + " ms"
+}
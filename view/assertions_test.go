@@ -0,0 +1,159 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_Assertions_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Results = append(measurement.Results, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", City: "Miami", ASN: 789, Network: "Network 3"},
+		Result: globalping.ProbeResult{
+			Status:   globalping.StatusFinished,
+			StatsRaw: json.RawMessage(`{"min":92.1,"avg":95.5,"max":98.9,"total":3,"rcv":2,"drop":1,"loss":33.33}`),
+		},
+	}, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "EU", Country: "PL", City: "Warsaw", ASN: 456, Network: "Network 2"},
+		Result: globalping.ProbeResult{
+			Status: globalping.StatusOffline,
+		},
+	})
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	maxLoss := 5.0
+	w := new(bytes.Buffer)
+	errW := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:    "ping",
+		CIMode: true,
+		Format: FormatCSV,
+		Assertions: Assertions{
+			MaxLoss:   &maxLoss,
+			MaxAvgRTT: 80 * time.Millisecond,
+		},
+	}, NewPrinter(nil, w, errW), nil, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.ErrorIs(t, err, ErrAssertionFailed)
+	assert.EqualError(t, err, "assertion failed: 3 of 5 checks failed")
+
+	// The report doesn't mix with the csv output
+	assert.Contains(t, w.String(), "id,type,target")
+	assert.Equal(t, `> Berlin, DE, EU, Deutsche Telekom AG (AS3320)
+PASS max-loss: 0% (expected <= 5%)
+PASS max-avg-rtt: 17.639 ms (expected <= 80 ms)
+
+> Miami, US, NA, Network 3 (AS789)
+FAIL max-loss: 33.33% (expected <= 5%)
+FAIL max-avg-rtt: 95.5 ms (expected <= 80 ms)
+
+> Warsaw, PL, EU, Network 2 (AS456)
+FAIL status: offline (expected finished)
+
+Assertions: 2 passed, 3 failed
+`, errW.String())
+}
+
+func Test_Output_Assertions_Passed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(createPingMeasurement(measurementID1), nil)
+
+	maxLoss := 0.0
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:        "ping",
+		CIMode:     true,
+		ToLatency:  true,
+		Assertions: Assertions{MaxLoss: &maxLoss},
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Deutsche Telekom AG (AS3320)
+Min: 17.64 ms
+Max: 17.64 ms
+Avg: 17.64 ms
+
+> Berlin, DE, EU, Deutsche Telekom AG (AS3320)
+PASS max-loss: 0% (expected <= 0%)
+
+Assertions: 1 passed, 0 failed
+`, w.String())
+}
+
+func Test_CheckAssertions(t *testing.T) {
+	maxLoss := 10.0
+	ctx := &Context{
+		Assertions: Assertions{
+			MaxLoss:         &maxLoss,
+			MaxAvgRTT:       10 * time.Millisecond,
+			MaxHTTPTotal:    500 * time.Millisecond,
+			ExpectStatus:    "200",
+			ExpectDNSAnswer: "5.6.7.8",
+		},
+	}
+	viewer := &viewer{ctx: ctx}
+
+	ctx.Cmd = "mtr"
+	results, err := viewer.checkAssertions(&globalping.ProbeResult{Status: globalping.StatusFinished, HopsRaw: testMTRHops})
+	assert.NoError(t, err)
+	assert.Equal(t, []assertionResult{
+		{Name: "max-loss", Actual: "33.3%", Expected: "<= 10%"},
+		{Name: "max-avg-rtt", Actual: "11.5 ms", Expected: "<= 10 ms"},
+	}, results)
+
+	ctx.Cmd = "http"
+	results, err = viewer.checkAssertions(&globalping.ProbeResult{
+		Status:     globalping.StatusFinished,
+		StatusCode: 200,
+		TimingsRaw: json.RawMessage(`{"total":583,"download":18,"firstByte":450,"dns":24,"tls":70,"tcp":19}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []assertionResult{
+		{Name: "expect-status", Actual: "200", Expected: "200", Passed: true},
+		{Name: "max-http-total", Actual: "583 ms", Expected: "<= 500 ms"},
+	}, results)
+
+	ctx.Cmd = "dns"
+	ctx.Assertions.ExpectStatus = "noerror"
+	results, err = viewer.checkAssertions(&globalping.ProbeResult{
+		Status:         globalping.StatusFinished,
+		StatusCodeName: "NOERROR",
+		AnswersRaw:     json.RawMessage(`[{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"1.2.3.4"},{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"5.6.7.8"}]`),
+		TimingsRaw:     json.RawMessage(`{"total":15}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []assertionResult{
+		{Name: "expect-status", Actual: "NOERROR", Expected: "noerror", Passed: true},
+		{Name: "expect-dns-answer", Actual: "1.2.3.4, 5.6.7.8", Expected: "5.6.7.8", Passed: true},
+	}, results)
+
+	results, err = viewer.checkAssertions(&globalping.ProbeResult{
+		Status:         globalping.StatusFinished,
+		StatusCodeName: "NXDOMAIN",
+		TimingsRaw:     json.RawMessage(`{"total":15}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []assertionResult{
+		{Name: "expect-status", Actual: "NXDOMAIN", Expected: "noerror"},
+		{Name: "expect-dns-answer", Actual: "no answers", Expected: "5.6.7.8"},
+	}, results)
+}
@@ -2,6 +2,7 @@ package view
 
 import (
 	"math"
+	"text/template"
 	"time"
 )
 
@@ -9,11 +10,24 @@ type Context struct {
 	Cmd       string
 	Target    string
 	From      string
-	Limit     int  // Number of probes to use
-	CIMode    bool // Determine whether the output should be in a format that is easy to parse by a CI tool
-	ToJSON    bool // Determines whether the output should be in JSON format.
-	ToLatency bool // Determines whether the output should be only the stats of a measurement
-	Share     bool // Display share message
+	Limit     int    // Number of probes to use
+	CIMode    bool   // Determine whether the output should be in a format that is easy to parse by a CI tool
+	ToJSON    bool   // Determines whether the output should be in JSON format.
+	ToLatency bool   // Determines whether the output should be only the stats of a measurement
+	Share     bool   // Display share message
+	ToTable   bool   // Determines whether the structured results should be output as tables
+	Format    string // Output format selected with the --format flag, e.g. csv or tsv
+	Verbose   bool   // Log additional details to stderr
+
+	WaitOnLimit bool          // Wait for the rate limit to reset instead of failing
+	Timeout     time.Duration // Maximum time to wait for the measurement to finish, 0 means no timeout
+	OutputFile  string        // File the results are appended to instead of stdout
+	JUnitFile   string        // File the JUnit XML report is written to
+	Assertions  Assertions    // Thresholds checked against the results of every probe
+
+	TemplateText string             // Template set with --template
+	TemplateFile string             // File containing the template, set with --template-file
+	Template     *template.Template // The parsed template, executed for every measurement
 
 	Packets   int // Number of packets to send
 	Port      int
@@ -27,11 +41,19 @@ type Context struct {
 	Headers   []string
 	Trace     bool
 	Full      bool // Full output
+	TLS       bool // Output the TLS certificate details
 	Infinite  bool // Infinite flag
 
+	Interval        time.Duration // Minimum time between the start of two measurements in continuous mode
+	Count           int           // Number of measurements to run in continuous mode, 0 means no limit
+	Duration        time.Duration // Time after which no new measurement is started in continuous mode, 0 means no limit
+	PacketsPerRound int           // Number of packets sent by every measurement of a continuous ping, 0 means 16
+
 	Head uint // Number of first measurements to show
 	Tail uint // Number of last measurements to show
 
+	Token string // API token provided to the auth login command
+
 	APIMinInterval time.Duration // Minimum interval between API calls
 
 	IsLocationFromSession bool // Determine whether the previous location is used
@@ -39,9 +61,14 @@ type Context struct {
 
 	Hostname            string
 	IsHeaderPrinted     bool
+	IsHTMLReportOpen    bool // Whether the html report was started and its closing tags must be printed
 	AggregatedStats     []*MeasurementStats
 	MeasurementsCreated int
 	History             *HistoryBuffer // History of measurements
+
+	PrometheusListen string   // Address to serve the Prometheus metrics of continuous measurements on
+	PrometheusPush   string   // Pushgateway URL to push the Prometheus metrics of continuous measurements to
+	Metrics          *Metrics // Receives the stats of continuous measurements, nil if metrics are disabled
 }
 
 type MeasurementStats struct {
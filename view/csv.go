@@ -0,0 +1,203 @@
+package view
+
+import (
+	"encoding/csv"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// Outputs the results of a measurement as CSV or TSV rows, one per probe or one per hop for traceroute and mtr.
+// The header is only printed if it differs from the previously printed one, so continuous measurements form a single table.
+func (v *viewer) OutputCSV(data *globalping.Measurement) error {
+	header, rows, err := v.csvRows(data)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(v.printer.OutWriter)
+	if v.ctx.Format == FormatTSV {
+		w.Comma = '\t'
+	}
+	h := strings.Join(header, ",")
+	if v.csvHeader != h {
+		v.csvHeader = h
+		err = w.Write(header)
+		if err != nil {
+			return err
+		}
+	}
+	err = w.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+var csvProbeColumns = []string{"id", "type", "target", "continent", "country", "state", "city", "asn", "network", "status"}
+
+func (v *viewer) csvRows(data *globalping.Measurement) ([]string, [][]string, error) {
+	var (
+		columns []string
+		decode  func(result *globalping.ProbeResult) ([][]string, error)
+	)
+	switch v.ctx.Cmd {
+	case "ping":
+		columns = []string{"packets_sent", "packets_received", "packet_loss", "rtt_min", "rtt_avg", "rtt_max", "rtt_mdev"}
+		decode = csvPingRows
+	case "dns":
+		columns = []string{"status_code", "resolver", "answers", "total"}
+		decode = csvDNSRows
+	case "http":
+		columns = []string{"status_code", "total", "dns", "tcp", "tls", "first_byte", "download"}
+		decode = csvHTTPRows
+	case "traceroute":
+		columns = []string{"hop", "hop_address", "hop_hostname", "rtt_min", "rtt_avg", "rtt_max"}
+		decode = csvTracerouteRows
+	case "mtr":
+		columns = []string{"hop", "hop_address", "hop_hostname", "hop_asn", "packets_sent", "packet_loss", "rtt_min", "rtt_avg", "rtt_max", "rtt_stdev"}
+		decode = csvMTRRows
+	default:
+		return nil, nil, errors.New("unexpected command for csv output: " + v.ctx.Cmd)
+	}
+
+	header := append(append([]string{}, csvProbeColumns...), columns...)
+	rows := [][]string{}
+	for i := range data.Results {
+		result := &data.Results[i]
+		probe := []string{
+			data.ID,
+			v.ctx.Cmd,
+			data.Target,
+			result.Probe.Continent,
+			result.Probe.Country,
+			result.Probe.State,
+			result.Probe.City,
+			strconv.Itoa(result.Probe.ASN),
+			result.Probe.Network,
+			string(result.Result.Status),
+		}
+		if result.Result.Status != globalping.StatusFinished {
+			rows = append(rows, append(probe, make([]string, len(columns))...))
+			continue
+		}
+		values, err := decode(&result.Result)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, row := range values {
+			rows = append(rows, append(append([]string{}, probe...), row...))
+		}
+	}
+	return header, rows, nil
+}
+
+func csvPingRows(result *globalping.ProbeResult) ([][]string, error) {
+	stats, err := globalping.DecodePingStats(result.StatsRaw)
+	if err != nil {
+		return nil, err
+	}
+	row := []string{strconv.Itoa(stats.Total), strconv.Itoa(stats.Rcv), formatFloat(stats.Loss), "", "", "", ""}
+	// Without replies, the RTT stats are not measured
+	if stats.Rcv > 0 {
+		row[3] = formatFloat(stats.Min)
+		row[4] = formatFloat(stats.Avg)
+		row[5] = formatFloat(stats.Max)
+		row[6] = formatFloat(stats.Mdev)
+	}
+	return [][]string{row}, nil
+}
+
+func csvDNSRows(result *globalping.ProbeResult) ([][]string, error) {
+	summary, err := summarizeDNSResult(result)
+	if err != nil {
+		return nil, err
+	}
+	return [][]string{{
+		result.StatusCodeName,
+		summary.Resolver,
+		strings.Join(summary.Answers, " "),
+		formatFloat(summary.Total),
+	}}, nil
+}
+
+func csvHTTPRows(result *globalping.ProbeResult) ([][]string, error) {
+	timings, err := globalping.DecodeHTTPTimings(result.TimingsRaw)
+	if err != nil {
+		return nil, err
+	}
+	return [][]string{{
+		strconv.Itoa(result.StatusCode),
+		strconv.Itoa(timings.Total),
+		strconv.Itoa(timings.DNS),
+		strconv.Itoa(timings.TCP),
+		strconv.Itoa(timings.TLS),
+		strconv.Itoa(timings.FirstByte),
+		strconv.Itoa(timings.Download),
+	}}, nil
+}
+
+func csvTracerouteRows(result *globalping.ProbeResult) ([][]string, error) {
+	hops, err := globalping.DecodeTracerouteHops(result.HopsRaw)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]string, len(hops))
+	for i := range hops {
+		hop := &hops[i]
+		row := []string{strconv.Itoa(i + 1), hop.ResolvedAddress, hop.ResolvedHostname, "", "", ""}
+		if len(hop.Timings) > 0 {
+			minRTT, maxRTT, sum := hop.Timings[0].RTT, hop.Timings[0].RTT, 0.0
+			for _, t := range hop.Timings {
+				minRTT = min(minRTT, t.RTT)
+				maxRTT = max(maxRTT, t.RTT)
+				sum += t.RTT
+			}
+			row[3] = formatFloat(minRTT)
+			row[4] = formatFloat(sum / float64(len(hop.Timings)))
+			row[5] = formatFloat(maxRTT)
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+func csvMTRRows(result *globalping.ProbeResult) ([][]string, error) {
+	hops, err := globalping.DecodeMTRHops(result.HopsRaw)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]string, len(hops))
+	for i := range hops {
+		hop := &hops[i]
+		asns := make([]string, len(hop.ASN))
+		for j, asn := range hop.ASN {
+			asns[j] = strconv.Itoa(asn)
+		}
+		row := []string{
+			strconv.Itoa(i + 1),
+			hop.ResolvedAddress,
+			hop.ResolvedHostname,
+			strings.Join(asns, " "),
+			strconv.Itoa(hop.Stats.Total),
+			formatFloat(hop.Stats.Loss),
+			"", "", "", "",
+		}
+		if hop.Stats.Rcv > 0 {
+			row[6] = formatFloat(hop.Stats.Min)
+			row[7] = formatFloat(hop.Stats.Avg)
+			row[8] = formatFloat(hop.Stats.Max)
+			row[9] = formatFloat(hop.Stats.StDev)
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// Formats the value with at most 3 decimals
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1000)/1000, 'f', -1, 64)
+}
@@ -0,0 +1,171 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_CSV_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Results = append(measurement.Results, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", State: "NY", City: "New York", ASN: 567, Network: "Network, Inc."},
+		Result: globalping.ProbeResult{
+			Status: globalping.StatusFailed,
+		},
+	}, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", State: "FL", City: "Miami", ASN: 789, Network: "Network 3"},
+		Result: globalping.ProbeResult{
+			Status:   globalping.StatusFinished,
+			StatsRaw: json.RawMessage(`{"min":null,"avg":null,"max":null,"mdev":null,"total":3,"rcv":0,"drop":3,"loss":100}`),
+		},
+	})
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:    "ping",
+		Format: FormatCSV,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `id,type,target,continent,country,state,city,asn,network,status,packets_sent,packets_received,packet_loss,rtt_min,rtt_avg,rtt_max,rtt_mdev
+`+measurementID1+`,ping,cdn.jsdelivr.net,EU,DE,,Berlin,3320,Deutsche Telekom AG,finished,1,1,0,17.639,17.639,17.639,0
+`+measurementID1+`,ping,cdn.jsdelivr.net,NA,US,NY,New York,567,"Network, Inc.",failed,,,,,,,
+`+measurementID1+`,ping,cdn.jsdelivr.net,NA,US,FL,Miami,789,Network 3,finished,3,0,100,,,,
+`, w.String())
+}
+
+func Test_Output_CSV_Infinite_Ping(t *testing.T) {
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Status = globalping.StatusInProgress
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:    "ping",
+		Format: FormatTSV,
+	}, NewPrinter(nil, w, w), nil, nil)
+
+	// Measurements in progress are not output
+	err := viewer.OutputInfinite(context.Background(), measurement)
+	assert.NoError(t, err)
+	assert.Equal(t, "", w.String())
+
+	measurement.Status = globalping.StatusFinished
+	err = viewer.OutputInfinite(context.Background(), measurement)
+	assert.NoError(t, err)
+
+	measurement2 := createPingMeasurement(measurementID2)
+	err = viewer.OutputInfinite(context.Background(), measurement2)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "id\ttype\ttarget\tcontinent\tcountry\tstate\tcity\tasn\tnetwork\tstatus\tpackets_sent\tpackets_received\tpacket_loss\trtt_min\trtt_avg\trtt_max\trtt_mdev\n"+
+		measurementID1+"\tping\tcdn.jsdelivr.net\tEU\tDE\t\tBerlin\t3320\tDeutsche Telekom AG\tfinished\t1\t1\t0\t17.639\t17.639\t17.639\t0\n"+
+		measurementID2+"\tping\tcdn.jsdelivr.net\tEU\tDE\t\tBerlin\t3320\tDeutsche Telekom AG\tfinished\t1\t1\t0\t17.639\t17.639\t17.639\t0\n",
+		w.String())
+}
+
+func Test_Output_CSV_DNS_HTTP(t *testing.T) {
+	probe := globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"}
+	dns := &globalping.Measurement{
+		ID:     measurementID1,
+		Target: "jsdelivr.com",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: probe,
+			Result: globalping.ProbeResult{
+				Status:         globalping.StatusFinished,
+				StatusCodeName: "NOERROR",
+				Resolver:       "1.1.1.1",
+				AnswersRaw:     json.RawMessage(`[{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"1.2.3.4"},{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"5.6.7.8"}]`),
+				TimingsRaw:     json.RawMessage(`{"total":15}`),
+			},
+		}},
+	}
+	http := &globalping.Measurement{
+		ID:     measurementID2,
+		Target: "jsdelivr.com",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: probe,
+			Result: globalping.ProbeResult{
+				Status:     globalping.StatusFinished,
+				StatusCode: 200,
+				TimingsRaw: json.RawMessage(`{"total":583,"download":18,"firstByte":450,"dns":24,"tls":70,"tcp":19}`),
+			},
+		}},
+	}
+
+	w := new(bytes.Buffer)
+	ctx := &Context{
+		Cmd:    "dns",
+		Format: FormatCSV,
+	}
+	viewer := &viewer{ctx: ctx, printer: NewPrinter(nil, w, w)}
+
+	err := viewer.OutputCSV(dns)
+	assert.NoError(t, err)
+	ctx.Cmd = "http"
+	err = viewer.OutputCSV(http)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `id,type,target,continent,country,state,city,asn,network,status,status_code,resolver,answers,total
+`+measurementID1+`,dns,jsdelivr.com,EU,DE,,Berlin,123,Network 1,finished,NOERROR,1.1.1.1,A 1.2.3.4 A 5.6.7.8,15
+id,type,target,continent,country,state,city,asn,network,status,status_code,total,dns,tcp,tls,first_byte,download
+`+measurementID2+`,http,jsdelivr.com,EU,DE,,Berlin,123,Network 1,finished,200,583,24,19,70,450,18
+`, w.String())
+}
+
+func Test_Output_CSV_Traceroute_MTR(t *testing.T) {
+	probe := globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"}
+	traceroute := &globalping.Measurement{
+		ID:     measurementID1,
+		Target: "1.1.1.1",
+		Results: []globalping.ProbeMeasurement{{
+			Probe:  probe,
+			Result: globalping.ProbeResult{Status: globalping.StatusFinished, HopsRaw: testTracerouteHops},
+		}},
+	}
+	mtr := &globalping.Measurement{
+		ID:     measurementID2,
+		Target: "1.1.1.1",
+		Results: []globalping.ProbeMeasurement{{
+			Probe:  probe,
+			Result: globalping.ProbeResult{Status: globalping.StatusFinished, HopsRaw: testMTRHops},
+		}},
+	}
+
+	w := new(bytes.Buffer)
+	ctx := &Context{
+		Cmd:    "traceroute",
+		Format: FormatCSV,
+	}
+	viewer := &viewer{ctx: ctx, printer: NewPrinter(nil, w, w)}
+
+	err := viewer.OutputCSV(traceroute)
+	assert.NoError(t, err)
+	ctx.Cmd = "mtr"
+	err = viewer.OutputCSV(mtr)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `id,type,target,continent,country,state,city,asn,network,status,hop,hop_address,hop_hostname,rtt_min,rtt_avg,rtt_max
+`+measurementID1+`,traceroute,1.1.1.1,EU,DE,,Berlin,123,Network 1,finished,1,10.0.0.1,gw.example.net,0.408,0.455,0.502
+`+measurementID1+`,traceroute,1.1.1.1,EU,DE,,Berlin,123,Network 1,finished,2,,,,,
+`+measurementID1+`,traceroute,1.1.1.1,EU,DE,,Berlin,123,Network 1,finished,3,1.1.1.1,one.one.one.one,10.5,11.5,12.5
+id,type,target,continent,country,state,city,asn,network,status,hop,hop_address,hop_hostname,hop_asn,packets_sent,packet_loss,rtt_min,rtt_avg,rtt_max,rtt_stdev
+`+measurementID2+`,mtr,1.1.1.1,EU,DE,,Berlin,123,Network 1,finished,1,10.0.0.1,10.0.0.1,,3,0,0.176,0.2,0.226,0.02
+`+measurementID2+`,mtr,1.1.1.1,EU,DE,,Berlin,123,Network 1,finished,2,,,,3,100,,,,
+`+measurementID2+`,mtr,1.1.1.1,EU,DE,,Berlin,123,Network 1,finished,3,1.1.1.1,one.one.one.one,13335,3,33.3,10.5,11.5,12.5,1
+`, w.String())
+}
@@ -16,7 +16,11 @@ func (v *viewer) outputDefault(id string, data *globalping.Measurement, m *globa
 		}
 
 		// Output slightly different format if state is available
-		v.printer.Println(v.getProbeInfo(result))
+		probeInfo := v.getProbeInfo(result)
+		if result.Result.Status == globalping.StatusInProgress {
+			probeInfo += " (timed out)"
+		}
+		v.printer.Println(probeInfo)
 
 		if v.isBodyOnlyHttpGet(m) {
 			v.printer.Println(strings.TrimSpace(result.Result.RawBody))
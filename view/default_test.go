@@ -2,6 +2,7 @@ package view
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"testing"
 
@@ -51,7 +52,7 @@ func Test_Output_Default_HTTP_Get(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	m := &globalping.MeasurementCreate{
 		Options: &globalping.MeasurementOptions{
@@ -67,7 +68,7 @@ func Test_Output_Default_HTTP_Get(t *testing.T) {
 		CIMode: true,
 	}, NewPrinter(nil, w, w), nil, gbMock)
 
-	viewer.Output(measurementID1, m)
+	viewer.Output(context.Background(), measurementID1, m)
 
 	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
 Body 1
@@ -117,7 +118,7 @@ func Test_Output_Default_HTTP_Get_Share(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	m := &globalping.MeasurementCreate{
 		Options: &globalping.MeasurementOptions{
@@ -133,7 +134,7 @@ func Test_Output_Default_HTTP_Get_Share(t *testing.T) {
 		Share:  true,
 	}, NewPrinter(nil, w, w), nil, gbMock)
 
-	viewer.Output(measurementID1, m)
+	viewer.Output(context.Background(), measurementID1, m)
 
 	assert.Equal(t, fmt.Sprintf(`> Berlin, DE, EU, Network 1 (AS123)
 Body 1
@@ -183,7 +184,7 @@ func Test_Output_Default_HTTP_Get_Full(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	m := &globalping.MeasurementCreate{
 		Options: &globalping.MeasurementOptions{
@@ -199,7 +200,7 @@ func Test_Output_Default_HTTP_Get_Full(t *testing.T) {
 		Full:   true,
 	}, NewPrinter(nil, w, w), nil, gbMock)
 
-	viewer.Output(measurementID1, m)
+	viewer.Output(context.Background(), measurementID1, m)
 
 	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
 Headers 1
@@ -249,7 +250,7 @@ func Test_Output_Default_HTTP_Head(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	m := &globalping.MeasurementCreate{
 		Options: &globalping.MeasurementOptions{
@@ -264,7 +265,7 @@ func Test_Output_Default_HTTP_Head(t *testing.T) {
 		CIMode: true,
 	}, NewPrinter(nil, w, w), nil, gbMock)
 
-	viewer.Output(measurementID1, m)
+	viewer.Output(context.Background(), measurementID1, m)
 
 	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
 Headers 1
@@ -310,7 +311,7 @@ func Test_Output_Default_Ping(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	m := &globalping.MeasurementCreate{}
 	w := new(bytes.Buffer)
@@ -319,7 +320,7 @@ func Test_Output_Default_Ping(t *testing.T) {
 		CIMode: true,
 	}, NewPrinter(nil, w, w), nil, gbMock)
 
-	viewer.Output(measurementID1, m)
+	viewer.Output(context.Background(), measurementID1, m)
 
 	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
 Ping Results 1
@@ -0,0 +1,145 @@
+package view
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// Outputs the answers of a dns measurement as a table for every probe,
+// marking the probes which received different answers than most of the others
+func (v *viewer) outputDNSTable(data *globalping.Measurement) error {
+	hops := make([][]globalping.DNSTraceHop, len(data.Results))
+	answerSets := make(map[int]string, len(data.Results))
+	for i := range data.Results {
+		result := &data.Results[i].Result
+		if result.Status != globalping.StatusFinished {
+			continue
+		}
+		h, err := decodeDNSHops(result)
+		if err != nil {
+			return err
+		}
+		hops[i] = h
+		if len(h) > 0 {
+			answerSets[i] = dnsAnswerSet(h[len(h)-1].Answers)
+		}
+	}
+	expectedSet, hasDifferences := mostCommon(answerSets)
+
+	for i := range data.Results {
+		result := &data.Results[i]
+		if i > 0 {
+			// new line as separator if more than 1 result
+			v.printer.Println()
+		}
+
+		probeInfo := v.getProbeInfo(result)
+		set, ok := answerSets[i]
+		if hasDifferences && ok && set != expectedSet {
+			probeInfo += " " + v.highlight("(different answers)")
+		}
+		if result.Result.Status == globalping.StatusInProgress {
+			probeInfo += " (timed out)"
+		}
+		v.printer.Println(probeInfo)
+
+		if result.Result.Status != globalping.StatusFinished {
+			v.printer.Println(strings.TrimSpace(result.Result.RawOutput))
+			continue
+		}
+		for j := range hops[i] {
+			if j > 0 {
+				v.printer.Println()
+			}
+			v.printer.Println(dnsHopInfo(&hops[i][j], result.Result.StatusCodeName))
+			v.printer.Print(v.dnsAnswersTable(hops[i][j].Answers))
+		}
+	}
+	return nil
+}
+
+// Returns the hops of a dns result, non-trace results are returned as a single hop
+func decodeDNSHops(result *globalping.ProbeResult) ([]globalping.DNSTraceHop, error) {
+	if len(result.HopsRaw) > 0 {
+		return globalping.DecodeDNSTraceHops(result.HopsRaw)
+	}
+	answers, err := globalping.DecodeDNSAnswers(result.AnswersRaw)
+	if err != nil {
+		return nil, err
+	}
+	hop := globalping.DNSTraceHop{
+		Resolver: result.Resolver,
+		Answers:  answers,
+	}
+	if len(result.TimingsRaw) > 0 {
+		timings, err := globalping.DecodeDNSTimings(result.TimingsRaw)
+		if err != nil {
+			return nil, err
+		}
+		hop.Timings = *timings
+	}
+	return []globalping.DNSTraceHop{hop}, nil
+}
+
+func dnsHopInfo(hop *globalping.DNSTraceHop, statusCodeName string) string {
+	info := make([]string, 0, 3)
+	if hop.Resolver != "" {
+		info = append(info, "Resolver: "+hop.Resolver)
+	}
+	if statusCodeName != "" {
+		info = append(info, "Status: "+statusCodeName)
+	}
+	info = append(info, fmt.Sprintf("Time: %v ms", hop.Timings.Total))
+	return strings.Join(info, ", ")
+}
+
+func (v *viewer) dnsAnswersTable(answers []globalping.DNSAnswer) string {
+	if len(answers) == 0 {
+		return "No answers\n"
+	}
+	rows := [][]string{{"Name", "Type", "TTL", "Class", "Value"}}
+	for _, a := range answers {
+		rows = append(rows, []string{a.Name, a.Type, strconv.Itoa(a.TTL), a.Class, a.Value})
+	}
+	return v.formatTable(rows)
+}
+
+// Returns a key identifying the answers regardless of their order and TTL
+func dnsAnswerSet(answers []globalping.DNSAnswer) string {
+	set := make([]string, len(answers))
+	for i, a := range answers {
+		set[i] = strings.ToLower(a.Type + " " + a.Value)
+	}
+	slices.Sort(set)
+	return strings.Join(set, ",")
+}
+
+type dnsSummary struct {
+	Resolver string   // The last resolver queried
+	Answers  []string // The answers of the last resolver, as "<type> <value>"
+	Total    float64  // The total time of all resolvers, in milliseconds
+}
+
+// Summarizes a dns result by the answers of the last resolver and the total time of all resolvers, which is the result itself for non-trace measurements
+func summarizeDNSResult(result *globalping.ProbeResult) (*dnsSummary, error) {
+	hops, err := decodeDNSHops(result)
+	if err != nil {
+		return nil, err
+	}
+	summary := &dnsSummary{Answers: []string{}}
+	for i := range hops {
+		summary.Total += hops[i].Timings.Total
+	}
+	if len(hops) > 0 {
+		last := &hops[len(hops)-1]
+		summary.Resolver = last.Resolver
+		for _, a := range last.Answers {
+			summary.Answers = append(summary.Answers, a.Type+" "+a.Value)
+		}
+	}
+	return summary, nil
+}
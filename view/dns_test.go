@@ -0,0 +1,153 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_Table_DNS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := &globalping.Measurement{
+		Status: globalping.StatusFinished,
+		Results: []globalping.ProbeMeasurement{
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+				Result: globalping.ProbeResult{
+					Status:         globalping.StatusFinished,
+					StatusCodeName: "NOERROR",
+					Resolver:       "1.1.1.1",
+					AnswersRaw:     json.RawMessage(`[{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"1.2.3.4"},{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"5.6.7.8"}]`),
+					TimingsRaw:     json.RawMessage(`{"total":15}`),
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "PL", City: "Warsaw", ASN: 456, Network: "Network 2"},
+				Result: globalping.ProbeResult{
+					Status:         globalping.StatusFinished,
+					StatusCodeName: "NOERROR",
+					Resolver:       "8.8.8.8",
+					AnswersRaw:     json.RawMessage(`[{"name":"jsdelivr.com.","type":"A","ttl":120,"class":"IN","value":"5.6.7.8"},{"name":"jsdelivr.com.","type":"A","ttl":120,"class":"IN","value":"1.2.3.4"}]`),
+					TimingsRaw:     json.RawMessage(`{"total":7}`),
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", State: "NY", City: "New York", ASN: 789, Network: "Network 3"},
+				Result: globalping.ProbeResult{
+					Status:         globalping.StatusFinished,
+					StatusCodeName: "NXDOMAIN",
+					Resolver:       "private",
+					TimingsRaw:     json.RawMessage(`{"total":3}`),
+				},
+			},
+		},
+	}
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:     "dns",
+		CIMode:  true,
+		ToTable: true,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
+Resolver: 1.1.1.1, Status: NOERROR, Time: 15 ms
+Name           Type  TTL  Class  Value
+jsdelivr.com.  A     30   IN     1.2.3.4
+jsdelivr.com.  A     30   IN     5.6.7.8
+
+> Warsaw, PL, EU, Network 2 (AS456)
+Resolver: 8.8.8.8, Status: NOERROR, Time: 7 ms
+Name           Type  TTL  Class  Value
+jsdelivr.com.  A     120  IN     5.6.7.8
+jsdelivr.com.  A     120  IN     1.2.3.4
+
+> New York (NY), US, NA, Network 3 (AS789) (different answers)
+Resolver: private, Status: NXDOMAIN, Time: 3 ms
+No answers
+
+`, w.String())
+}
+
+func Test_Output_Table_DNS_Trace(t *testing.T) {
+	measurement := &globalping.Measurement{
+		Status: globalping.StatusFinished,
+		Results: []globalping.ProbeMeasurement{
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+				Result: globalping.ProbeResult{
+					Status: globalping.StatusFinished,
+					HopsRaw: json.RawMessage(`[
+						{"resolver":"a.root-servers.net","answers":[{"name":"com.","type":"NS","ttl":172800,"class":"IN","value":"a.gtld-servers.net."}],"timings":{"total":12}},
+						{"resolver":"ns1.jsdelivr.com","answers":[{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"1.2.3.4"}],"timings":{"total":8}}
+					]`),
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "PL", City: "Warsaw", ASN: 456, Network: "Network 2"},
+				Result: globalping.ProbeResult{
+					Status:    globalping.StatusInProgress,
+					RawOutput: "partial output",
+				},
+			},
+		},
+	}
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{
+		ctx: &Context{
+			Cmd:     "dns",
+			CIMode:  true,
+			ToTable: true,
+			Share:   true,
+		},
+		printer: NewPrinter(nil, w, w),
+	}
+
+	err := viewer.OutputTable(measurementID1, measurement)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
+Resolver: a.root-servers.net, Time: 12 ms
+Name  Type  TTL     Class  Value
+com.  NS    172800  IN     a.gtld-servers.net.
+
+Resolver: ns1.jsdelivr.com, Time: 8 ms
+Name           Type  TTL  Class  Value
+jsdelivr.com.  A     30   IN     1.2.3.4
+
+> Warsaw, PL, EU, Network 2 (AS456) (timed out)
+partial output
+> View the results online: https://www.jsdelivr.com/globalping?measurement=`+measurementID1+`
+
+`, w.String())
+}
+
+func Test_Output_Table_Unsupported(t *testing.T) {
+	w := new(bytes.Buffer)
+	viewer := &viewer{
+		ctx: &Context{
+			Cmd:     "ping",
+			CIMode:  true,
+			ToTable: true,
+		},
+		printer: NewPrinter(nil, w, w),
+	}
+
+	err := viewer.OutputTable(measurementID1, &globalping.Measurement{})
+	assert.EqualError(t, err, "unexpected command for table output: ping")
+}
@@ -0,0 +1,189 @@
+package view
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// Output formats selected with the --format flag
+const (
+	FormatCSV         = "csv"
+	FormatTSV         = "tsv"
+	FormatInflux      = "influx"
+	FormatOpenMetrics = "openmetrics"
+	FormatMarkdown    = "markdown"
+	FormatHTML        = "html"
+	FormatJSON        = "json"
+	FormatLatency     = "latency"
+	FormatTable       = "table"
+	FormatTLS         = "tls"
+	FormatTemplate    = "template"
+	FormatNDJSON      = "ndjson"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported output format")
+
+// An output format, selected with the --format flag or one of the dedicated flags such as --json
+type format struct {
+	// Outputs the results of a finished measurement, or the partial results if the timeout was reached
+	output func(v *viewer, ctx context.Context, id string, data *globalping.Measurement) error
+	// Outputs the state of a continuous measurement on every update, including the measurements in progress.
+	// Formats without it output every measurement once it's finished.
+	outputInfinite func(v *viewer, ctx context.Context, m *globalping.Measurement) error
+	// The commands supporting the format, all if empty
+	commands []string
+}
+
+// The available output formats. Adding a format only requires implementing its hooks and registering it here.
+var formats = map[string]*format{
+	FormatCSV: {
+		output: func(v *viewer, _ context.Context, _ string, data *globalping.Measurement) error {
+			return v.OutputCSV(data)
+		},
+	},
+	FormatTSV: {
+		output: func(v *viewer, _ context.Context, _ string, data *globalping.Measurement) error {
+			return v.OutputCSV(data)
+		},
+	},
+	FormatInflux: {
+		output: func(v *viewer, _ context.Context, _ string, data *globalping.Measurement) error {
+			return v.OutputInflux(data)
+		},
+		commands: []string{"ping", "dns", "http"},
+	},
+	FormatOpenMetrics: {
+		output: func(v *viewer, _ context.Context, _ string, data *globalping.Measurement) error {
+			return v.OutputOpenMetrics(data)
+		},
+		commands: []string{"ping", "dns", "http"},
+	},
+	FormatMarkdown: {
+		output: func(v *viewer, _ context.Context, id string, data *globalping.Measurement) error {
+			return v.OutputMarkdown(id, data)
+		},
+	},
+	FormatHTML: {
+		output: func(v *viewer, _ context.Context, id string, data *globalping.Measurement) error {
+			return v.OutputHTML(id, data)
+		},
+	},
+	FormatJSON: {
+		output: func(v *viewer, ctx context.Context, id string, data *globalping.Measurement) error {
+			if data.Status == globalping.StatusInProgress {
+				// The partial results of a measurement which timed out
+				return v.outputDecodedJson(id, data)
+			}
+			return v.OutputJson(ctx, id)
+		},
+	},
+	FormatLatency: {
+		output: func(v *viewer, _ context.Context, id string, data *globalping.Measurement) error {
+			return v.OutputLatency(id, data)
+		},
+		outputInfinite: func(v *viewer, _ context.Context, m *globalping.Measurement) error {
+			return v.outputInfiniteStats(m, false)
+		},
+	},
+	FormatTable: {
+		output: func(v *viewer, _ context.Context, id string, data *globalping.Measurement) error {
+			return v.OutputTable(id, data)
+		},
+		commands: []string{"dns", "mtr", "traceroute"},
+	},
+	FormatTLS: {
+		output: func(v *viewer, _ context.Context, id string, data *globalping.Measurement) error {
+			return v.OutputTLS(id, data)
+		},
+		commands: []string{"http"},
+	},
+	FormatNDJSON: {
+		output: func(v *viewer, _ context.Context, _ string, data *globalping.Measurement) error {
+			return v.OutputNDJSON(data)
+		},
+		outputInfinite: func(v *viewer, _ context.Context, m *globalping.Measurement) error {
+			return v.OutputNDJSON(m)
+		},
+		commands: []string{"ping"},
+	},
+	FormatTemplate: {
+		output: func(v *viewer, _ context.Context, _ string, data *globalping.Measurement) error {
+			return v.OutputTemplate(data)
+		},
+	},
+}
+
+// Returns the names of the available output formats
+func FormatNames() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Returns an error if the format doesn't exist or doesn't support the command. The command is not checked if empty.
+func ValidateFormat(name string, cmd string) error {
+	f, ok := formats[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, name)
+	}
+	if cmd != "" && len(f.commands) > 0 && !slices.Contains(f.commands, cmd) {
+		return fmt.Errorf("%w: %s is only supported by the %s", ErrUnsupportedFormat, name, joinCommands(f.commands))
+	}
+	return nil
+}
+
+func joinCommands(commands []string) string {
+	if len(commands) == 1 {
+		return commands[0] + " command"
+	}
+	return strings.Join(commands[:len(commands)-1], ", ") + " and " + commands[len(commands)-1] + " commands"
+}
+
+// Returns the output format selected with the flags, nil for the default output
+func (v *viewer) selectedFormat() *format {
+	name := SelectedFormat(v.ctx)
+	if name == "" {
+		return nil
+	}
+	return formats[name]
+}
+
+// Returns the name of the output format selected with the --format flag or its equivalent flags, or "" for the default output
+func SelectedFormat(ctx *Context) string {
+	switch {
+	case ctx.Format != "":
+		return ctx.Format
+	case ctx.ToLatency:
+		return FormatLatency
+	case ctx.ToJSON:
+		return FormatJSON
+	case ctx.ToTable:
+		return FormatTable
+	case ctx.TLS:
+		return FormatTLS
+	}
+	return ""
+}
+
+// Outputs the measurement decoded by the client, used when the raw response is not available
+func (v *viewer) outputDecodedJson(id string, data *globalping.Measurement) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	v.printer.Println(string(b))
+	if v.ctx.Share {
+		v.printer.Println(v.getShareMessage(id))
+	}
+	v.printer.Println()
+	return nil
+}
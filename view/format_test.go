@@ -0,0 +1,93 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_Format_Json(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	measurement := createPingMeasurement(measurementID1)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurementRaw(gomock.Any(), measurementID1).Times(1).Return([]byte(`{"fake": "results"}`), nil)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:    "ping",
+		Format: FormatJSON,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"fake\": \"results\"}\n\n", w.String())
+}
+
+func Test_OutputInfinite_Format_Json(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurementRaw(gomock.Any(), measurementID1).Times(1).Return([]byte(`{"fake": "results"}`), nil)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:    "ping",
+		Format: FormatJSON,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	// Measurements in progress are skipped by formats without a streaming hook
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Status = globalping.StatusInProgress
+	err := viewer.OutputInfinite(context.Background(), measurement)
+	assert.NoError(t, err)
+	assert.Equal(t, "", w.String())
+
+	measurement.Status = globalping.StatusFinished
+	err = viewer.OutputInfinite(context.Background(), measurement)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"fake\": \"results\"}\n\n", w.String())
+}
+
+func Test_SelectedFormat(t *testing.T) {
+	ctx := &Context{}
+	viewer := &viewer{ctx: ctx}
+	assert.Nil(t, viewer.selectedFormat())
+
+	ctx.ToJSON = true
+	assert.Equal(t, formats[FormatJSON], viewer.selectedFormat())
+
+	ctx.ToLatency = true
+	assert.Equal(t, formats[FormatLatency], viewer.selectedFormat())
+
+	ctx.Format = FormatCSV
+	assert.Equal(t, formats[FormatCSV], viewer.selectedFormat())
+}
+
+func Test_ValidateFormat(t *testing.T) {
+	assert.NoError(t, ValidateFormat(FormatCSV, "ping"))
+	assert.NoError(t, ValidateFormat(FormatTable, "mtr"))
+	assert.NoError(t, ValidateFormat(FormatTable, ""))
+
+	err := ValidateFormat("yaml", "ping")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	assert.EqualError(t, err, "unsupported output format: yaml")
+
+	err = ValidateFormat(FormatTable, "ping")
+	assert.EqualError(t, err, "unsupported output format: table is only supported by the dns, mtr and traceroute commands")
+
+	err = ValidateFormat(FormatTLS, "dns")
+	assert.EqualError(t, err, "unsupported output format: tls is only supported by the http command")
+}
+
+func Test_FormatNames(t *testing.T) {
+	assert.Equal(t, []string{"csv", "html", "influx", "json", "latency", "markdown", "ndjson", "openmetrics", "table", "template", "tls", "tsv"}, FormatNames())
+}
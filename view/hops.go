@@ -0,0 +1,176 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// Outputs the hops of a traceroute measurement as a table for every probe
+func (v *viewer) outputTracerouteTable(data *globalping.Measurement) error {
+	tables := make([][][]string, len(data.Results))
+	for i := range data.Results {
+		result := &data.Results[i].Result
+		if result.Status != globalping.StatusFinished {
+			continue
+		}
+		hops, err := globalping.DecodeTracerouteHops(result.HopsRaw)
+		if err != nil {
+			return err
+		}
+		rows := [][]string{{"Hop", "Host", "RTT"}}
+		for j := range hops {
+			hop := &hops[j]
+			rtts := make([]string, len(hop.Timings))
+			for k := range hop.Timings {
+				rtts[k] = formatDuration(hop.Timings[k].RTT)
+			}
+			rtt := "*"
+			if len(rtts) > 0 {
+				rtt = strings.Join(rtts, "  ")
+			}
+			rows = append(rows, []string{strconv.Itoa(j + 1), hopHost(hop.ResolvedHostname, hop.ResolvedAddress), rtt})
+		}
+		tables[i] = rows
+	}
+	v.outputProbeTables(data, tables)
+	return nil
+}
+
+// Outputs the hops of an mtr measurement as a table for every probe
+func (v *viewer) outputMTRTable(data *globalping.Measurement) error {
+	tables := make([][][]string, len(data.Results))
+	for i := range data.Results {
+		result := &data.Results[i].Result
+		if result.Status != globalping.StatusFinished {
+			continue
+		}
+		hops, err := globalping.DecodeMTRHops(result.HopsRaw)
+		if err != nil {
+			return err
+		}
+		tables[i] = mtrHopRows(hops)
+	}
+	v.outputProbeTables(data, tables)
+	return nil
+}
+
+// Returns the rows of the hops table of an mtr result, the first row is the header
+func mtrHopRows(hops []globalping.MTRHop) [][]string {
+	rows := [][]string{{"Hop", "Host", "ASN", "Loss", "Sent", "Last", "Avg", "Best", "Worst", "StDev"}}
+	for j := range hops {
+		hop := &hops[j]
+		row := []string{
+			strconv.Itoa(j + 1),
+			hopHost(hop.ResolvedHostname, hop.ResolvedAddress),
+			formatASNs(hop.ASN),
+			fmt.Sprintf("%.1f%%", hop.Stats.Loss),
+			strconv.Itoa(hop.Stats.Total),
+		}
+		if hop.Stats.Rcv == 0 || len(hop.Timings) == 0 {
+			row = append(row, "-", "-", "-", "-", "-")
+		} else {
+			row = append(row,
+				formatDuration(hop.Timings[len(hop.Timings)-1].RTT),
+				formatDuration(hop.Stats.Avg),
+				formatDuration(hop.Stats.Min),
+				formatDuration(hop.Stats.Max),
+				formatDuration(hop.Stats.StDev),
+			)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Outputs the table of every probe with the columns aligned across all the probes,
+// the raw output is used for the probes without a table
+func (v *viewer) outputProbeTables(data *globalping.Measurement, tables [][][]string) {
+	widths := columnWidths(tables...)
+	for i := range data.Results {
+		result := &data.Results[i]
+		if i > 0 {
+			// new line as separator if more than 1 result
+			v.printer.Println()
+		}
+
+		probeInfo := v.getProbeInfo(result)
+		if result.Result.Status == globalping.StatusInProgress {
+			probeInfo += " (timed out)"
+		}
+		v.printer.Println(probeInfo)
+
+		if tables[i] == nil {
+			v.printer.Println(strings.TrimSpace(result.Result.RawOutput))
+			continue
+		}
+		v.printer.Print(v.formatTableWithWidths(tables[i], widths))
+	}
+}
+
+// Outputs the latency summary of the last hop of a traceroute result
+func (v *viewer) outputTracerouteLatency(result *globalping.ProbeResult) error {
+	hops, err := globalping.DecodeTracerouteHops(result.HopsRaw)
+	if err != nil {
+		return err
+	}
+	v.printer.Println(v.latencyStatHeader("Hops") + strconv.Itoa(len(hops)))
+	if len(hops) == 0 || len(hops[len(hops)-1].Timings) == 0 {
+		v.printer.Println(v.latencyStatHeader("Status") + "no reply from the target")
+		return nil
+	}
+	timings := hops[len(hops)-1].Timings
+	minRTT, maxRTT, sum := timings[0].RTT, timings[0].RTT, 0.0
+	for i := range timings {
+		minRTT = min(minRTT, timings[i].RTT)
+		maxRTT = max(maxRTT, timings[i].RTT)
+		sum += timings[i].RTT
+	}
+	v.printer.Println(v.latencyStatHeader("Min") + fmt.Sprintf("%.2f ms", minRTT))
+	v.printer.Println(v.latencyStatHeader("Max") + fmt.Sprintf("%.2f ms", maxRTT))
+	v.printer.Println(v.latencyStatHeader("Avg") + fmt.Sprintf("%.2f ms", sum/float64(len(timings))))
+	return nil
+}
+
+// Outputs the latency summary of the last hop of an mtr result
+func (v *viewer) outputMTRLatency(result *globalping.ProbeResult) error {
+	hops, err := globalping.DecodeMTRHops(result.HopsRaw)
+	if err != nil {
+		return err
+	}
+	v.printer.Println(v.latencyStatHeader("Hops") + strconv.Itoa(len(hops)))
+	if len(hops) == 0 || hops[len(hops)-1].Stats.Rcv == 0 {
+		v.printer.Println(v.latencyStatHeader("Status") + "no reply from the target")
+		return nil
+	}
+	stats := &hops[len(hops)-1].Stats
+	v.printer.Println(v.latencyStatHeader("Loss") + fmt.Sprintf("%.2f%%", stats.Loss))
+	v.printer.Println(v.latencyStatHeader("Min") + fmt.Sprintf("%.2f ms", stats.Min))
+	v.printer.Println(v.latencyStatHeader("Max") + fmt.Sprintf("%.2f ms", stats.Max))
+	v.printer.Println(v.latencyStatHeader("Avg") + fmt.Sprintf("%.2f ms", stats.Avg))
+	return nil
+}
+
+// Returns the hostname and address of a hop, or "*" if the hop did not reply
+func hopHost(hostname, address string) string {
+	if address == "" {
+		return "*"
+	}
+	if hostname == "" || hostname == address {
+		return address
+	}
+	return hostname + " (" + address + ")"
+}
+
+func formatASNs(asns []int) string {
+	if len(asns) == 0 {
+		return "-"
+	}
+	s := make([]string, len(asns))
+	for i, asn := range asns {
+		s[i] = "AS" + strconv.Itoa(asn)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,175 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testTracerouteHops = json.RawMessage(`[
+		{"resolvedHostname":"gw.example.net","resolvedAddress":"10.0.0.1","timings":[{"rtt":0.408},{"rtt":0.502}]},
+		{"resolvedHostname":null,"resolvedAddress":null,"timings":[]},
+		{"resolvedHostname":"one.one.one.one","resolvedAddress":"1.1.1.1","timings":[{"rtt":12.5},{"rtt":10.5}]}
+	]`)
+	testMTRHops = json.RawMessage(`[
+		{"resolvedHostname":"10.0.0.1","resolvedAddress":"10.0.0.1","asn":[],"stats":{"min":0.176,"avg":0.2,"max":0.226,"stDev":0.02,"total":3,"rcv":3,"drop":0,"loss":0},"timings":[{"rtt":0.176},{"rtt":0.216},{"rtt":0.226}]},
+		{"resolvedHostname":null,"resolvedAddress":null,"asn":[],"stats":{"min":0,"avg":0,"max":0,"stDev":0,"total":3,"rcv":0,"drop":3,"loss":100},"timings":[]},
+		{"resolvedHostname":"one.one.one.one","resolvedAddress":"1.1.1.1","asn":[13335],"stats":{"min":10.5,"avg":11.5,"max":12.5,"stDev":1,"total":3,"rcv":2,"drop":1,"loss":33.3},"timings":[{"rtt":12.5},{"rtt":10.5}]}
+	]`)
+)
+
+func Test_Output_Table_Traceroute(t *testing.T) {
+	measurement := &globalping.Measurement{
+		Results: []globalping.ProbeMeasurement{
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+				Result: globalping.ProbeResult{
+					Status:  globalping.StatusFinished,
+					HopsRaw: testTracerouteHops,
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "PL", City: "Warsaw", ASN: 456, Network: "Network 2"},
+				Result: globalping.ProbeResult{
+					Status:  globalping.StatusFinished,
+					HopsRaw: json.RawMessage(`[{"resolvedHostname":"1.1.1.1","resolvedAddress":"1.1.1.1","timings":[{"rtt":3}]}]`),
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", City: "Miami", ASN: 789, Network: "Network 3"},
+				Result: globalping.ProbeResult{
+					Status:    globalping.StatusFailed,
+					RawOutput: "traceroute failed",
+				},
+			},
+		},
+	}
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{
+		ctx: &Context{
+			Cmd:     "traceroute",
+			CIMode:  true,
+			ToTable: true,
+		},
+		printer: NewPrinter(nil, w, w),
+	}
+
+	err := viewer.OutputTable(measurementID1, measurement)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
+Hop  Host                       RTT
+1    gw.example.net (10.0.0.1)  0.41 ms  0.50 ms
+2    *                          *
+3    one.one.one.one (1.1.1.1)  12.5 ms  10.5 ms
+
+> Warsaw, PL, EU, Network 2 (AS456)
+Hop  Host                       RTT
+1    1.1.1.1                    3.00 ms
+
+> Miami, US, NA, Network 3 (AS789)
+traceroute failed
+
+`, w.String())
+}
+
+func Test_Output_Table_MTR(t *testing.T) {
+	measurement := &globalping.Measurement{
+		Results: []globalping.ProbeMeasurement{
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+				Result: globalping.ProbeResult{
+					Status:  globalping.StatusFinished,
+					HopsRaw: testMTRHops,
+				},
+			},
+		},
+	}
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{
+		ctx: &Context{
+			Cmd:     "mtr",
+			CIMode:  true,
+			ToTable: true,
+		},
+		printer: NewPrinter(nil, w, w),
+	}
+
+	err := viewer.OutputTable(measurementID1, measurement)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
+Hop  Host                       ASN      Loss    Sent  Last     Avg      Best     Worst    StDev
+1    10.0.0.1                   -        0.0%    3     0.23 ms  0.20 ms  0.18 ms  0.23 ms  0.02 ms
+2    *                          -        100.0%  3     -        -        -        -        -
+3    one.one.one.one (1.1.1.1)  AS13335  33.3%   3     10.5 ms  11.5 ms  10.5 ms  12.5 ms  1.00 ms
+
+`, w.String())
+}
+
+func Test_Output_Latency_Traceroute_And_MTR(t *testing.T) {
+	measurement := &globalping.Measurement{
+		Results: []globalping.ProbeMeasurement{
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+				Result: globalping.ProbeResult{
+					Status:  globalping.StatusFinished,
+					HopsRaw: testTracerouteHops,
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "PL", City: "Warsaw", ASN: 456, Network: "Network 2"},
+				Result: globalping.ProbeResult{
+					Status:  globalping.StatusFinished,
+					HopsRaw: json.RawMessage(`[{"resolvedHostname":null,"resolvedAddress":null,"timings":[]}]`),
+				},
+			},
+		},
+	}
+
+	w := new(bytes.Buffer)
+	ctx := &Context{
+		Cmd:       "traceroute",
+		CIMode:    true,
+		ToLatency: true,
+	}
+	viewer := &viewer{ctx: ctx, printer: NewPrinter(nil, w, w)}
+
+	err := viewer.OutputLatency(measurementID1, measurement)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
+Hops: 3
+Min: 10.50 ms
+Max: 12.50 ms
+Avg: 11.50 ms
+
+> Warsaw, PL, EU, Network 2 (AS456)
+Hops: 1
+Status: no reply from the target
+
+`, w.String())
+
+	w.Reset()
+	ctx.Cmd = "mtr"
+	measurement.Results = measurement.Results[:1]
+	measurement.Results[0].Result.HopsRaw = testMTRHops
+
+	err = viewer.OutputLatency(measurementID1, measurement)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
+Hops: 3
+Loss: 33.30%
+Min: 10.50 ms
+Max: 12.50 ms
+Avg: 11.50 ms
+
+`, w.String())
+}
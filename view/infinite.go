@@ -2,6 +2,7 @@ package view
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -17,25 +18,44 @@ var (
 	colSeparator = " | "
 )
 
-func (v *viewer) OutputInfinite(m *globalping.Measurement) error {
-	if v.ctx.ToJSON {
-		if m.Status == globalping.StatusInProgress {
-			return nil
-		}
-		return v.OutputJson(m.ID)
+func (v *viewer) OutputInfinite(ctx context.Context, m *globalping.Measurement) error {
+	f := v.selectedFormat()
+	if f == nil {
+		return v.outputInfiniteStats(m, true)
+	}
+	if f.outputInfinite != nil {
+		return f.outputInfinite(v, ctx, m)
+	}
+	if m.Status == globalping.StatusInProgress {
+		return nil
 	}
+	return f.output(v, ctx, m.ID, m)
+}
 
+// Outputs the packets of a single probe as they arrive if streaming is allowed, or a table with the stats of every probe.
+// The dns and http commands always output a table with the response times of every probe, the mtr command the merged hops of every probe.
+func (v *viewer) outputInfiniteStats(m *globalping.Measurement, streaming bool) error {
+	switch v.ctx.Cmd {
+	case "dns", "http":
+		return v.outputInfiniteTimings(m)
+	case "mtr":
+		return v.outputInfiniteMTR(m)
+	}
 	if isFailedMeasurement(m) {
 		return v.outputFailSummary(m)
 	}
 
-	if len(m.Results) == 1 {
-		if v.ctx.ToLatency {
-			return v.outputTableView(m)
-		}
-		return v.outputStreamingPackets(m)
+	var err error
+	if len(m.Results) == 1 && streaming {
+		err = v.outputStreamingPackets(m)
+	} else {
+		err = v.outputTableView(m)
 	}
-	return v.outputTableView(m)
+	if err != nil {
+		return err
+	}
+	v.updateMetrics(m)
+	return nil
 }
 
 func (v *viewer) outputStreamingPackets(m *globalping.Measurement) error {
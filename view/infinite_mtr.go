@@ -0,0 +1,112 @@
+package view
+
+import (
+	"math"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// The stats of a hop merged across the measurements of a continuous mtr measurement
+type mtrHopStats struct {
+	hop   globalping.MTRHop // The last reply of the hop, with the merged stats
+	count int               // Number of round-trip times
+	tsum  float64           // Total sum of the round-trip times
+	tsum2 float64           // Total sum of the round-trip times squared
+}
+
+// Merges the stats of the hop of a finished measurement
+func (s *mtrHopStats) merge(hop *globalping.MTRHop) {
+	if hop.ResolvedAddress != "" || s.hop.ResolvedAddress == "" {
+		s.hop.ResolvedAddress = hop.ResolvedAddress
+		s.hop.ResolvedHostname = hop.ResolvedHostname
+		s.hop.ASN = hop.ASN
+	}
+	stats := &s.hop.Stats
+	stats.Total += hop.Stats.Total
+	stats.Rcv += hop.Stats.Rcv
+	stats.Drop = stats.Total - stats.Rcv
+	if stats.Total > 0 {
+		stats.Loss = math.Round(float64(stats.Drop)/float64(stats.Total)*1000) / 10
+	}
+	for _, t := range hop.Timings {
+		if s.count == 0 {
+			stats.Min, stats.Max = t.RTT, t.RTT
+		}
+		s.count++
+		stats.Min = math.Min(stats.Min, t.RTT)
+		stats.Max = math.Max(stats.Max, t.RTT)
+		s.tsum += t.RTT
+		s.tsum2 += t.RTT * t.RTT
+	}
+	if s.count > 0 {
+		stats.Avg = s.tsum / float64(s.count)
+		stats.StDev = math.Sqrt(math.Max(s.tsum2/float64(s.count)-stats.Avg*stats.Avg, 0))
+	}
+	if len(hop.Timings) > 0 {
+		// Only the last round-trip time is output
+		s.hop.Timings = hop.Timings[len(hop.Timings)-1:]
+	}
+}
+
+// Merges the hops of every probe of a finished mtr measurement with the previous ones and redraws the hop tables
+func (v *viewer) outputInfiniteMTR(m *globalping.Measurement) error {
+	if m.Status == globalping.StatusInProgress {
+		return nil
+	}
+	if len(v.mtrStats) == 0 && isFailedMeasurement(m) {
+		return v.outputFailSummary(m)
+	}
+	for i := range m.Results {
+		result := &m.Results[i].Result
+		if i == len(v.mtrStats) {
+			v.mtrStats = append(v.mtrStats, nil)
+		}
+		if result.Status != globalping.StatusFinished {
+			continue
+		}
+		hops, err := globalping.DecodeMTRHops(result.HopsRaw)
+		if err != nil {
+			return err
+		}
+		for j := range hops {
+			if j == len(v.mtrStats[i]) {
+				v.mtrStats[i] = append(v.mtrStats[i], &mtrHopStats{})
+			}
+			v.mtrStats[i][j].merge(&hops[j])
+		}
+	}
+	o := v.generateMTRTables(m)
+	v.printer.AreaUpdate(&o)
+	return nil
+}
+
+// Returns the merged hops table of every probe, with the columns aligned across all the probes.
+// The raw output of the last measurement is used for the probes without replies yet.
+func (v *viewer) generateMTRTables(m *globalping.Measurement) string {
+	tables := make([][][]string, len(v.mtrStats))
+	for i, stats := range v.mtrStats {
+		if len(stats) == 0 {
+			continue
+		}
+		hops := make([]globalping.MTRHop, len(stats))
+		for j := range stats {
+			hops[j] = stats[j].hop
+		}
+		tables[i] = mtrHopRows(hops)
+	}
+	widths := columnWidths(tables...)
+	output := &strings.Builder{}
+	for i := range m.Results {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		output.WriteString(v.getProbeInfo(&m.Results[i]) + "\n")
+		if i >= len(tables) || tables[i] == nil {
+			output.WriteString(strings.TrimSpace(m.Results[i].Result.RawOutput) + "\n")
+			continue
+		}
+		output.WriteString(v.formatTableWithWidths(tables[i], widths))
+	}
+	return output.String()
+}
@@ -0,0 +1,100 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OutputInfinite_MTR(t *testing.T) {
+	ctx := createDefaultContext("mtr")
+	ctx.CIMode = true
+	w := new(bytes.Buffer)
+	v := &viewer{ctx: ctx, printer: NewPrinter(nil, w, w)}
+
+	m := &globalping.Measurement{
+		ID:     measurementID1,
+		Status: globalping.StatusFinished,
+		Results: []globalping.ProbeMeasurement{
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+				Result: globalping.ProbeResult{
+					Status:  globalping.StatusFinished,
+					HopsRaw: testMTRHops,
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", City: "Miami", ASN: 789, Network: "Network 3"},
+				Result: globalping.ProbeResult{
+					Status:    globalping.StatusFailed,
+					RawOutput: "mtr failed",
+				},
+			},
+		},
+	}
+	err := v.OutputInfinite(context.Background(), m)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
+Hop  Host                       ASN      Loss    Sent  Last     Avg      Best     Worst    StDev
+1    10.0.0.1                   -        0.0%    3     0.23 ms  0.21 ms  0.18 ms  0.23 ms  0.02 ms
+2    *                          -        100.0%  3     -        -        -        -        -
+3    one.one.one.one (1.1.1.1)  AS13335  33.3%   3     10.5 ms  11.5 ms  10.5 ms  12.5 ms  1.00 ms
+
+> Miami, US, NA, Network 3 (AS789)
+mtr failed
+`, w.String())
+
+	// The hops of the next measurement are merged with the previous ones
+	m.ID = measurementID2
+	m.Results[0].Result.HopsRaw = json.RawMessage(`[
+		{"resolvedHostname":"10.0.0.1","resolvedAddress":"10.0.0.1","asn":[],"stats":{"total":3,"rcv":3},"timings":[{"rtt":0.2},{"rtt":0.2},{"rtt":0.2}]},
+		{"resolvedHostname":"10.0.0.2","resolvedAddress":"10.0.0.2","asn":[],"stats":{"total":3,"rcv":3},"timings":[{"rtt":5},{"rtt":5},{"rtt":5}]},
+		{"resolvedHostname":"one.one.one.one","resolvedAddress":"1.1.1.1","asn":[13335],"stats":{"total":3,"rcv":3},"timings":[{"rtt":11.5},{"rtt":11.5},{"rtt":11.5}]}
+	]`)
+	m.Results[1].Result = globalping.ProbeResult{
+		Status:  globalping.StatusFinished,
+		HopsRaw: json.RawMessage(`[{"resolvedHostname":"1.1.1.1","resolvedAddress":"1.1.1.1","asn":[13335],"stats":{"total":3,"rcv":3},"timings":[{"rtt":3},{"rtt":3},{"rtt":3}]}]`),
+	}
+	w.Reset()
+	err = v.OutputInfinite(context.Background(), m)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "\033[8A\033[0J"+`> Berlin, DE, EU, Network 1 (AS123)
+Hop  Host                       ASN      Loss   Sent  Last     Avg      Best     Worst    StDev
+1    10.0.0.1                   -        0.0%   6     0.20 ms  0.20 ms  0.18 ms  0.23 ms  0.02 ms
+2    10.0.0.2                   -        50.0%  6     5.00 ms  5.00 ms  5.00 ms  5.00 ms  0.00 ms
+3    one.one.one.one (1.1.1.1)  AS13335  16.7%  6     11.5 ms  11.5 ms  10.5 ms  12.5 ms  0.63 ms
+
+> Miami, US, NA, Network 3 (AS789)
+Hop  Host                       ASN      Loss   Sent  Last     Avg      Best     Worst    StDev
+1    1.1.1.1                    AS13335  0.0%   3     3.00 ms  3.00 ms  3.00 ms  3.00 ms  0.00 ms
+`, w.String())
+}
+
+func Test_OutputInfinite_MTR_All_Failed(t *testing.T) {
+	ctx := createDefaultContext("mtr")
+	ctx.CIMode = true
+	w := new(bytes.Buffer)
+	v := &viewer{ctx: ctx, printer: NewPrinter(nil, w, w)}
+
+	m := &globalping.Measurement{
+		ID:     measurementID1,
+		Status: globalping.StatusFinished,
+		Results: []globalping.ProbeMeasurement{{
+			Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", City: "Miami", ASN: 789, Network: "Network 3"},
+			Result: globalping.ProbeResult{
+				Status:    globalping.StatusFailed,
+				RawOutput: "mtr failed",
+			},
+		}},
+	}
+	err := v.OutputInfinite(context.Background(), m)
+	assert.EqualError(t, err, "all probes failed")
+	assert.Equal(t, "> Miami, US, NA, Network 3 (AS789)\nmtr failed\n", w.String())
+	assert.Empty(t, v.mtrStats)
+}
@@ -2,6 +2,7 @@ package view
 
 import (
 	"bytes"
+	"context"
 	"math"
 	"testing"
 	"time"
@@ -30,7 +31,7 @@ func Test_OutputInfinite_SingleProbe_InProgress(t *testing.T) {
 	measurement.Results[0].Result.Status = globalping.StatusInProgress
 	measurement.Results[0].Result.RawOutput = `PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.`
 
-	err := viewer.OutputInfinite(measurement)
+	err := viewer.OutputInfinite(context.Background(), measurement)
 	assert.NoError(t, err)
 
 	assert.Equal(t,
@@ -43,7 +44,7 @@ PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.
 	measurement.Results[0].Result.RawOutput = `PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.
 64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=1 ttl=56 time=12.9 ms`
 
-	err = viewer.OutputInfinite(measurement)
+	err = viewer.OutputInfinite(context.Background(), measurement)
 	assert.NoError(t, err)
 
 	assert.Equal(t,
@@ -58,7 +59,7 @@ PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.
 64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=1 ttl=56 time=12.9 ms
 64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=2 ttl=56 time=12.7 ms`
 
-	err = viewer.OutputInfinite(measurement)
+	err = viewer.OutputInfinite(context.Background(), measurement)
 	assert.NoError(t, err)
 
 	assert.Equal(t,
@@ -85,7 +86,7 @@ PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.
 3 packets transmitted, 3 received, 0% packet loss, time 1001ms
 rtt min/avg/max/mdev = 12.711/12.854/12.952/0.103 ms`
 
-	err = viewer.OutputInfinite(measurement)
+	err = viewer.OutputInfinite(context.Background(), measurement)
 	assert.NoError(t, err)
 
 	assert.Equal(t,
@@ -108,7 +109,7 @@ PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.
 		StartedAt: defaultCurrentTime.Add(1 * time.Millisecond),
 	})
 	measurement.ID = measurementID2
-	err = viewer.OutputInfinite(measurement)
+	err = viewer.OutputInfinite(context.Background(), measurement)
 	assert.NoError(t, err)
 
 	assert.Equal(t,
@@ -139,7 +140,7 @@ func Test_OutputInfinite_SingleProbe_Failed(t *testing.T) {
 	ctx.CIMode = true
 	w := new(bytes.Buffer)
 	viewer := NewViewer(ctx, NewPrinter(nil, w, w), nil, nil)
-	err := viewer.OutputInfinite(measurement)
+	err := viewer.OutputInfinite(context.Background(), measurement)
 	assert.Equal(t, "all probes failed", err.Error())
 
 	assert.Equal(t,
@@ -175,7 +176,7 @@ London, GB, EU, OVH SAS (AS0)                  |    0 |   0.00% |        - |
 Falkenstein, DE, EU, Hetzner Online GmbH (AS0) |    1 |   0.00% |  5.46 ms |  5.46 ms |  5.46 ms |  5.46 ms
 Nuremberg, DE, EU, Hetzner Online GmbH (AS0)   |    1 |   0.00% |  4.07 ms |  4.07 ms |  4.07 ms |  4.07 ms
 `
-	err := viewer.OutputInfinite(measurement)
+	err := viewer.OutputInfinite(context.Background(), measurement)
 	assert.NoError(t, err)
 
 	expectedStats := []*MeasurementStats{
@@ -199,7 +200,7 @@ Falkenstein, DE, EU, Hetzner Online GmbH (AS0) |    1 |   0.00% |  5.46 ms |  5.
 Nuremberg, DE, EU, Hetzner Online GmbH (AS0)   |    1 |   0.00% |  4.07 ms |  4.07 ms |  4.07 ms |  4.07 ms
 `
 
-	err = viewer.OutputInfinite(measurement)
+	err = viewer.OutputInfinite(context.Background(), measurement)
 	assert.NoError(t, err)
 
 	assertMeasurementStats(t, expectedStats[0], ctx.AggregatedStats[0])
@@ -226,7 +227,7 @@ Falkenstein, DE, EU, Hetzner Online GmbH (AS0) |    1 |   0.00% |  5.46 ms |  5.
 Nuremberg, DE, EU, Hetzner Online GmbH (AS0)   |    1 |   0.00% |  4.07 ms |  4.07 ms |  4.07 ms |  4.07 ms
 `
 
-	err = viewer.OutputInfinite(measurement)
+	err = viewer.OutputInfinite(context.Background(), measurement)
 	assert.NoError(t, err)
 
 	expectedStats = []*MeasurementStats{
@@ -247,7 +248,7 @@ Nuremberg, DE, EU, Hetzner Online GmbH (AS0)   |    1 |   0.00% |  4.07 ms |  4.
 		StartedAt: defaultCurrentTime.Add(1 * time.Millisecond),
 	})
 
-	err = viewer.OutputInfinite(measurement2)
+	err = viewer.OutputInfinite(context.Background(), measurement2)
 	assert.NoError(t, err)
 
 	expectedStats = []*MeasurementStats{
@@ -297,7 +298,7 @@ Falkenstein, DE, EU, Hetzner Online GmbH (AS0) |    0 |   0.00% |        - |
 Nuremberg, DE, EU, Hetzner Online GmbH (AS0)   |    1 |   0.00% |  4.07 ms |  4.07 ms |  4.07 ms |  4.07 ms
 `
 
-	err := viewer.OutputInfinite(measurement1)
+	err := viewer.OutputInfinite(context.Background(), measurement1)
 	assert.NoError(t, err)
 
 	// Call 2
@@ -321,7 +322,7 @@ Falkenstein, DE, EU, Hetzner Online GmbH (AS0) |    1 |   0.00% |  20.0 ms |  20
 Nuremberg, DE, EU, Hetzner Online GmbH (AS0)   |    2 |   0.00% |  4.07 ms |  4.07 ms |  4.07 ms |  4.07 ms
 `
 
-	err = viewer.OutputInfinite(measurement2)
+	err = viewer.OutputInfinite(context.Background(), measurement2)
 	assert.NoError(t, err)
 
 	// Call 3
@@ -336,7 +337,7 @@ Falkenstein, DE, EU, Hetzner Online GmbH (AS0) |    3 |   0.00% |  20.0 ms |  20
 Nuremberg, DE, EU, Hetzner Online GmbH (AS0)   |    2 |   0.00% |  4.07 ms |  4.07 ms |  4.07 ms |  4.07 ms
 `
 
-	err = viewer.OutputInfinite(measurement1)
+	err = viewer.OutputInfinite(context.Background(), measurement1)
 	assert.NoError(t, err)
 
 	// Call 4
@@ -368,7 +369,7 @@ Falkenstein, DE, EU, Hetzner Online GmbH (AS0) |    4 |   0.00% |  20.0 ms |  20
 Nuremberg, DE, EU, Hetzner Online GmbH (AS0)   |    2 |   0.00% |  4.07 ms |  4.07 ms |  4.07 ms |  4.07 ms
 `
 
-	err = viewer.OutputInfinite(measurement1)
+	err = viewer.OutputInfinite(context.Background(), measurement1)
 	assert.NoError(t, err)
 
 	// Call 5
@@ -382,7 +383,7 @@ Nuremberg, DE, EU, Hetzner Online GmbH (AS0)   |    2 |   0.00% |  4.07 ms |  4.
 3 packets transmitted, 3 received, 0% packet loss, time 100ms
 rtt min/avg/max/mdev = 10/15/25/5 ms`
 
-	err = viewer.OutputInfinite(measurement2)
+	err = viewer.OutputInfinite(context.Background(), measurement2)
 	assert.NoError(t, err)
 
 	expectedOutput += "\033[4A\033[0J" +
@@ -403,7 +404,7 @@ func Test_OutputInfinite_MultipleProbes(t *testing.T) {
 	ctx := createDefaultContext("ping")
 	w := new(bytes.Buffer)
 	v := NewViewer(ctx, NewPrinter(nil, w, w), nil, nil)
-	err := v.OutputInfinite(measurement)
+	err := v.OutputInfinite(context.Background(), measurement)
 	assert.NoError(t, err)
 
 	expectedOutput := "\033[96mLocation                                      \033[0m | \033[96mSent\033[0m | \033[96m   Loss\033[0m | \033[96m    Last\033[0m | \033[96m     Min\033[0m | \033[96m     Avg\033[0m | \033[96m     Max\033[0m" +
@@ -435,7 +436,7 @@ func Test_OutputInfinite_MultipleProbes_All_Failed(t *testing.T) {
 	ctx.CIMode = true
 	w := new(bytes.Buffer)
 	v := NewViewer(ctx, NewPrinter(nil, w, w), nil, nil)
-	err := v.OutputInfinite(measurement)
+	err := v.OutputInfinite(context.Background(), measurement)
 
 	assert.Equal(t, "all probes failed", err.Error())
 	assert.Equal(t, `> London, GB, EU, OVH SAS (AS0)
@@ -0,0 +1,143 @@
+package view
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// The response times and results of a probe across the measurements of a continuous dns or http measurement
+type probeTimingStats struct {
+	Location string  // The location of the probe
+	Count    int     // Number of finished results
+	Failed   int     // Number of results which didn't finish
+	Last     float64 // Last response time
+	Min      float64 // Minimum response time
+	Avg      float64 // Average response time
+	Max      float64 // Maximum response time
+	Tsum     float64 // Total sum of the response times
+	Result   string  // The last dns answers or http status, or the status of the probe if it didn't finish
+	Changes  []resultChange
+}
+
+// A change of the result of a probe between two measurements
+type resultChange struct {
+	Time time.Time
+	From string
+	To   string
+}
+
+func newProbeTimingStats(location string) *probeTimingStats {
+	return &probeTimingStats{Location: location, Min: math.MaxFloat64}
+}
+
+// Adds the result of a finished measurement to the stats
+func (s *probeTimingStats) add(total float64, result string, finished bool, t time.Time) {
+	if finished {
+		s.Count++
+		s.Last = total
+		s.Min = math.Min(s.Min, total)
+		s.Max = math.Max(s.Max, total)
+		s.Tsum += total
+		s.Avg = s.Tsum / float64(s.Count)
+	} else {
+		s.Failed++
+	}
+	if s.Result != "" && s.Result != result {
+		s.Changes = append(s.Changes, resultChange{Time: t, From: s.Result, To: result})
+	}
+	s.Result = result
+}
+
+// Adds the results of a finished dns or http measurement to the stats of every probe and redraws the table
+func (v *viewer) outputInfiniteTimings(m *globalping.Measurement) error {
+	if m.Status == globalping.StatusInProgress {
+		return nil
+	}
+	if len(v.timingStats) == 0 && isFailedMeasurement(m) {
+		return v.outputFailSummary(m)
+	}
+	t, err := time.Parse(time.RFC3339Nano, m.CreatedAt)
+	if err != nil {
+		t = v.time.Now()
+	}
+	for i := range m.Results {
+		result := &m.Results[i]
+		if i == len(v.timingStats) {
+			v.timingStats = append(v.timingStats, newProbeTimingStats(getLocationText(result)))
+		}
+		if result.Result.Status != globalping.StatusFinished {
+			v.timingStats[i].add(0, string(result.Result.Status), false, t)
+			continue
+		}
+		total, value, err := v.timingResult(&result.Result)
+		if err != nil {
+			return err
+		}
+		v.timingStats[i].add(total, value, true, t)
+	}
+	o := v.generateTimingsTable()
+	v.printer.AreaUpdate(&o)
+	return nil
+}
+
+// Returns the response time and the result tracked for changes: the status code and the sorted answers for dns, the status code for http
+func (v *viewer) timingResult(result *globalping.ProbeResult) (float64, string, error) {
+	if v.ctx.Cmd == "dns" {
+		summary, err := summarizeDNSResult(result)
+		if err != nil {
+			return 0, "", err
+		}
+		slices.Sort(summary.Answers)
+		return summary.Total, strings.TrimSpace(result.StatusCodeName + " " + strings.Join(summary.Answers, ", ")), nil
+	}
+	timings, err := globalping.DecodeHTTPTimings(result.TimingsRaw)
+	if err != nil {
+		return 0, "", err
+	}
+	return float64(timings.Total), strconv.Itoa(result.StatusCode), nil
+}
+
+func (v *viewer) generateTimingsTable() string {
+	result := "Answers"
+	if v.ctx.Cmd == "http" {
+		result = "Status"
+	}
+	rows := [][]string{{"Location", "Count", "Failed", "Last", "Min", "Avg", "Max", "Changes", result}}
+	for _, s := range v.timingStats {
+		row := []string{s.Location, strconv.Itoa(s.Count), strconv.Itoa(s.Failed)}
+		row = append(row, getTimingValues(s)...)
+		rows = append(rows, append(row, strconv.Itoa(len(s.Changes)), s.Result))
+	}
+	return v.formatTable(rows)
+}
+
+func getTimingValues(s *probeTimingStats) []string {
+	values := []string{"-", "-", "-", "-"}
+	if s.Count == 0 {
+		return values
+	}
+	for i, ms := range []float64{s.Last, s.Min, s.Avg, s.Max} {
+		values[i] = formatDuration(ms)
+	}
+	return values
+}
+
+// Outputs the response times and the result changes of every probe of a continuous dns or http measurement
+func (v *viewer) outputTimingsSummary() {
+	v.printer.Printf("\n--- %s %s statistics ---\n", v.ctx.Target, v.ctx.Cmd)
+	for _, s := range v.timingStats {
+		v.printer.Printf("%s: %d finished, %d failed", s.Location, s.Count, s.Failed)
+		if s.Count > 0 {
+			v.printer.Printf(", time min/avg/max = %.3f/%.3f/%.3f ms", s.Min, s.Avg, s.Max)
+		}
+		v.printer.Println()
+		for _, c := range s.Changes {
+			v.printer.Printf("  %s changed from %s to %s\n", c.Time.UTC().Format(time.DateTime), c.From, c.To)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/stretchr/testify/assert"
+)
+
+func createHTTPMeasurement(id string, createdAt string, statusCode int, total int) *globalping.Measurement {
+	return &globalping.Measurement{
+		ID:        id,
+		Type:      "http",
+		Status:    globalping.StatusFinished,
+		CreatedAt: createdAt,
+		Target:    "jsdelivr.com",
+		Results: []globalping.ProbeMeasurement{
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+				Result: globalping.ProbeResult{
+					Status:     globalping.StatusFinished,
+					StatusCode: statusCode,
+					TimingsRaw: json.RawMessage(`{"total":` + fmt.Sprint(total) + `}`),
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", State: "NY", City: "New York", ASN: 456, Network: "Network 2"},
+				Result: globalping.ProbeResult{
+					Status: globalping.StatusFailed,
+				},
+			},
+		},
+	}
+}
+
+func Test_OutputInfinite_HTTP(t *testing.T) {
+	ctx := createDefaultContext("http")
+	ctx.CIMode = true
+	w := new(bytes.Buffer)
+	v := NewViewer(ctx, NewPrinter(nil, w, w), nil, nil)
+
+	err := v.OutputInfinite(context.Background(), createHTTPMeasurement(measurementID1, "2024-01-18T14:09:41.250Z", 200, 120))
+	assert.NoError(t, err)
+	assert.Equal(t, `Location                                  Count  Failed  Last    Min     Avg     Max     Changes  Status
+Berlin, DE, EU, Network 1 (AS123)         1      0       120 ms  120 ms  120 ms  120 ms  0        200
+New York (NY), US, NA, Network 2 (AS456)  0      1       -       -       -       -       0        failed
+`, w.String())
+}
+
+func Test_OutputInfinite_HTTP_Changes(t *testing.T) {
+	ctx := createDefaultContext("http")
+	ctx.CIMode = true
+	ctx.Target = "jsdelivr.com"
+	w := new(bytes.Buffer)
+	v := &viewer{ctx: ctx, printer: NewPrinter(nil, w, w)}
+
+	// In progress measurements are skipped
+	m := createHTTPMeasurement(measurementID1, "2024-01-18T14:09:41.250Z", 200, 120)
+	m.Status = globalping.StatusInProgress
+	err := v.OutputInfinite(context.Background(), m)
+	assert.NoError(t, err)
+	assert.Empty(t, v.timingStats)
+
+	err = v.OutputInfinite(context.Background(), createHTTPMeasurement(measurementID1, "2024-01-18T14:09:41.250Z", 200, 120))
+	assert.NoError(t, err)
+	err = v.OutputInfinite(context.Background(), createHTTPMeasurement(measurementID2, "2024-01-18T14:09:43.250Z", 503, 80))
+	assert.NoError(t, err)
+	m = createHTTPMeasurement(measurementID2, "2024-01-18T14:09:45.250Z", 200, 100)
+	m.Results[1].Result.Status = globalping.StatusFinished
+	m.Results[1].Result.StatusCode = 200
+	m.Results[1].Result.TimingsRaw = json.RawMessage(`{"total":300}`)
+	err = v.OutputInfinite(context.Background(), m)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &probeTimingStats{
+		Location: "Berlin, DE, EU, Network 1 (AS123)",
+		Count:    3,
+		Last:     100,
+		Min:      80,
+		Avg:      100,
+		Max:      120,
+		Tsum:     300,
+		Result:   "200",
+		Changes: []resultChange{
+			{Time: time.Date(2024, 1, 18, 14, 9, 43, 250000000, time.UTC), From: "200", To: "503"},
+			{Time: time.Date(2024, 1, 18, 14, 9, 45, 250000000, time.UTC), From: "503", To: "200"},
+		},
+	}, v.timingStats[0])
+
+	w.Reset()
+	v.OutputSummary()
+	assert.Equal(t, `
+--- jsdelivr.com http statistics ---
+Berlin, DE, EU, Network 1 (AS123): 3 finished, 0 failed, time min/avg/max = 80.000/100.000/120.000 ms
+  2024-01-18 14:09:43 changed from 200 to 503
+  2024-01-18 14:09:45 changed from 503 to 200
+New York (NY), US, NA, Network 2 (AS456): 1 finished, 2 failed, time min/avg/max = 300.000/300.000/300.000 ms
+  2024-01-18 14:09:45 changed from failed to 200
+`, w.String())
+}
+
+func Test_OutputInfinite_DNS(t *testing.T) {
+	ctx := createDefaultContext("dns")
+	ctx.CIMode = true
+	w := new(bytes.Buffer)
+	v := &viewer{ctx: ctx, printer: NewPrinter(nil, w, w)}
+
+	m := &globalping.Measurement{
+		ID:        measurementID1,
+		Status:    globalping.StatusFinished,
+		CreatedAt: "2024-01-18T14:09:41.250Z",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+			Result: globalping.ProbeResult{
+				Status:         globalping.StatusFinished,
+				StatusCodeName: "NOERROR",
+				TimingsRaw:     json.RawMessage(`{"total":15}`),
+				AnswersRaw:     json.RawMessage(`[{"type":"A","value":"104.16.89.20"},{"type":"A","value":"104.16.85.20"}]`),
+			},
+		}},
+	}
+	err := v.OutputInfinite(context.Background(), m)
+	assert.NoError(t, err)
+
+	// The answers are sorted so a different order isn't reported as a change
+	w.Reset()
+	m.Results[0].Result.AnswersRaw = json.RawMessage(`[{"type":"A","value":"104.16.85.20"},{"type":"A","value":"104.16.89.20"}]`)
+	err = v.OutputInfinite(context.Background(), m)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "\033[2A\033[0J"+`Location                           Count  Failed  Last     Min      Avg      Max      Changes  Answers
+Berlin, DE, EU, Network 1 (AS123)  2      0       15.0 ms  15.0 ms  15.0 ms  15.0 ms  0        NOERROR A 104.16.85.20, A 104.16.89.20
+`, w.String())
+}
+
+func Test_OutputInfinite_DNS_All_Failed(t *testing.T) {
+	ctx := createDefaultContext("dns")
+	ctx.CIMode = true
+	w := new(bytes.Buffer)
+	v := &viewer{ctx: ctx, printer: NewPrinter(nil, w, w)}
+
+	m := createHTTPMeasurement(measurementID1, "", 0, 0)
+	m.Results = m.Results[1:]
+	m.Results[0].Result.RawOutput = "connection refused"
+	err := v.OutputInfinite(context.Background(), m)
+	assert.EqualError(t, err, "all probes failed")
+	assert.Equal(t, "> New York (NY), US, NA, Network 2 (AS456)\nconnection refused\n", w.String())
+	assert.Empty(t, v.timingStats)
+}
@@ -0,0 +1,138 @@
+package view
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// Prefix of the InfluxDB measurements the results are written to, followed by the command.
+// Every command has its own measurement as the same field names have different types, e.g. the dns and http status codes.
+const influxMeasurementPrefix = "globalping_"
+
+// Outputs the results of a measurement in the InfluxDB line protocol, one line per probe.
+// The probe index and location are written as tags and the timings as fields, using the creation time of the measurement as timestamp.
+// The probe index keeps the series of probes sharing the same location apart.
+func (v *viewer) OutputInflux(data *globalping.Measurement) error {
+	var decode func(result *globalping.ProbeResult, fields *influxFields) error
+	switch v.ctx.Cmd {
+	case "ping":
+		decode = influxPingFields
+	case "dns":
+		decode = influxDNSFields
+	case "http":
+		decode = influxHTTPFields
+	default:
+		return errors.New("unexpected command for influx output: " + v.ctx.Cmd)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, data.CreatedAt)
+	if err != nil {
+		timestamp = v.time.Now()
+	}
+	for i := range data.Results {
+		result := &data.Results[i]
+		line := &strings.Builder{}
+		line.WriteString(influxMeasurementPrefix + v.ctx.Cmd)
+		for _, tag := range [][2]string{
+			{"type", v.ctx.Cmd},
+			{"target", data.Target},
+			{"probe", strconv.Itoa(i)},
+			{"continent", result.Probe.Continent},
+			{"country", result.Probe.Country},
+			{"state", result.Probe.State},
+			{"city", result.Probe.City},
+			{"asn", strconv.Itoa(result.Probe.ASN)},
+			{"network", result.Probe.Network},
+		} {
+			// Empty tag values are not allowed
+			if tag[1] != "" {
+				line.WriteString("," + tag[0] + "=" + influxTagReplacer.Replace(tag[1]))
+			}
+		}
+
+		fields := &influxFields{}
+		fields.String("id", data.ID)
+		fields.String("status", string(result.Result.Status))
+		if result.Result.Status == globalping.StatusFinished {
+			err := decode(&result.Result, fields)
+			if err != nil {
+				return err
+			}
+		}
+		line.WriteString(" " + strings.Join(fields.values, ","))
+		line.WriteString(" " + strconv.FormatInt(timestamp.UnixNano(), 10))
+		v.printer.Println(line.String())
+	}
+	return nil
+}
+
+func influxPingFields(result *globalping.ProbeResult, fields *influxFields) error {
+	stats, err := globalping.DecodePingStats(result.StatsRaw)
+	if err != nil {
+		return err
+	}
+	fields.Int("packets_sent", stats.Total)
+	fields.Int("packets_received", stats.Rcv)
+	fields.Float("packet_loss", stats.Loss)
+	if stats.Rcv > 0 {
+		fields.Float("rtt_min", stats.Min)
+		fields.Float("rtt_avg", stats.Avg)
+		fields.Float("rtt_max", stats.Max)
+		fields.Float("rtt_mdev", stats.Mdev)
+	}
+	return nil
+}
+
+func influxDNSFields(result *globalping.ProbeResult, fields *influxFields) error {
+	summary, err := summarizeDNSResult(result)
+	if err != nil {
+		return err
+	}
+	fields.String("status_code", result.StatusCodeName)
+	fields.String("resolver", summary.Resolver)
+	fields.String("answers", strings.Join(summary.Answers, " "))
+	fields.Int("answer_count", len(summary.Answers))
+	fields.Float("total", summary.Total)
+	return nil
+}
+
+func influxHTTPFields(result *globalping.ProbeResult, fields *influxFields) error {
+	timings, err := globalping.DecodeHTTPTimings(result.TimingsRaw)
+	if err != nil {
+		return err
+	}
+	fields.Int("status_code", result.StatusCode)
+	fields.Int("total", timings.Total)
+	fields.Int("dns", timings.DNS)
+	fields.Int("tcp", timings.TCP)
+	fields.Int("tls", timings.TLS)
+	fields.Int("first_byte", timings.FirstByte)
+	fields.Int("download", timings.Download)
+	return nil
+}
+
+var (
+	influxTagReplacer    = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `, "\n", `\n`)
+	influxStringReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+// The field set of a line, as "<key>=<value>" pairs
+type influxFields struct {
+	values []string
+}
+
+func (f *influxFields) String(key string, value string) {
+	f.values = append(f.values, key+`="`+influxStringReplacer.Replace(value)+`"`)
+}
+
+func (f *influxFields) Int(key string, value int) {
+	f.values = append(f.values, key+"="+strconv.Itoa(value)+"i")
+}
+
+func (f *influxFields) Float(key string, value float64) {
+	f.values = append(f.values, key+"="+formatFloat(value))
+}
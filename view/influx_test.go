@@ -0,0 +1,123 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_Influx_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Results = append(measurement.Results, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", State: "NY", City: "New York", ASN: 567, Network: "Network, Inc."},
+		Result: globalping.ProbeResult{
+			Status: globalping.StatusFailed,
+		},
+	})
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:    "ping",
+		Format: FormatInflux,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `globalping_ping,type=ping,target=cdn.jsdelivr.net,probe=0,continent=EU,country=DE,city=Berlin,asn=3320,network=Deutsche\ Telekom\ AG id="`+measurementID1+`",status="finished",packets_sent=1i,packets_received=1i,packet_loss=0,rtt_min=17.639,rtt_avg=17.639,rtt_max=17.639,rtt_mdev=0 1705586981250000000
+globalping_ping,type=ping,target=cdn.jsdelivr.net,probe=1,continent=NA,country=US,state=NY,city=New\ York,asn=567,network=Network\,\ Inc. id="`+measurementID1+`",status="failed" 1705586981250000000
+`, w.String())
+}
+
+func Test_Output_Influx_DNS_HTTP(t *testing.T) {
+	probe := globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"}
+	dns := &globalping.Measurement{
+		ID:        measurementID1,
+		Target:    "jsdelivr.com",
+		CreatedAt: "2024-01-18T14:09:41.250Z",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: probe,
+			Result: globalping.ProbeResult{
+				Status:         globalping.StatusFinished,
+				StatusCodeName: "NOERROR",
+				Resolver:       "1.1.1.1",
+				AnswersRaw:     json.RawMessage(`[{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"1.2.3.4"},{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"5.6.7.8"}]`),
+				TimingsRaw:     json.RawMessage(`{"total":15}`),
+			},
+		}},
+	}
+	http := &globalping.Measurement{
+		ID:        measurementID2,
+		Target:    "jsdelivr.com",
+		CreatedAt: "2024-01-18T14:09:42Z",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: probe,
+			Result: globalping.ProbeResult{
+				Status:     globalping.StatusFinished,
+				StatusCode: 200,
+				TimingsRaw: json.RawMessage(`{"total":583,"download":18,"firstByte":450,"dns":24,"tls":70,"tcp":19}`),
+			},
+		}},
+	}
+
+	w := new(bytes.Buffer)
+	ctx := &Context{
+		Cmd:    "dns",
+		Format: FormatInflux,
+	}
+	viewer := &viewer{ctx: ctx, printer: NewPrinter(nil, w, w)}
+
+	err := viewer.OutputInflux(dns)
+	assert.NoError(t, err)
+	ctx.Cmd = "http"
+	err = viewer.OutputInflux(http)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `globalping_dns,type=dns,target=jsdelivr.com,probe=0,continent=EU,country=DE,city=Berlin,asn=123,network=Network\ 1 id="`+measurementID1+`",status="finished",status_code="NOERROR",resolver="1.1.1.1",answers="A 1.2.3.4 A 5.6.7.8",answer_count=2i,total=15 1705586981250000000
+globalping_http,type=http,target=jsdelivr.com,probe=0,continent=EU,country=DE,city=Berlin,asn=123,network=Network\ 1 id="`+measurementID2+`",status="finished",status_code=200i,total=583i,dns=24i,tcp=19i,tls=70i,first_byte=450i,download=18i 1705586982000000000
+`, w.String())
+}
+
+func Test_Output_Influx_Same_Location(t *testing.T) {
+	probe := globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"}
+	measurement := &globalping.Measurement{
+		ID:        measurementID1,
+		Target:    "jsdelivr.com",
+		CreatedAt: "2024-01-18T14:09:42Z",
+		Results: []globalping.ProbeMeasurement{
+			{Probe: probe, Result: globalping.ProbeResult{Status: globalping.StatusFinished, StatusCode: 200, TimingsRaw: json.RawMessage(`{"total":583}`)}},
+			{Probe: probe, Result: globalping.ProbeResult{Status: globalping.StatusFinished, StatusCode: 200, TimingsRaw: json.RawMessage(`{"total":120}`)}},
+		},
+	}
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "http", Format: FormatInflux}, printer: NewPrinter(nil, w, w)}
+
+	err := viewer.OutputInflux(measurement)
+	assert.NoError(t, err)
+
+	// The probes have different series keys so neither point is overwritten
+	assert.Equal(t, `globalping_http,type=http,target=jsdelivr.com,probe=0,continent=EU,country=DE,city=Berlin,asn=123,network=Network\ 1 id="`+measurementID1+`",status="finished",status_code=200i,total=583i,dns=0i,tcp=0i,tls=0i,first_byte=0i,download=0i 1705586982000000000
+globalping_http,type=http,target=jsdelivr.com,probe=1,continent=EU,country=DE,city=Berlin,asn=123,network=Network\ 1 id="`+measurementID1+`",status="finished",status_code=200i,total=120i,dns=0i,tcp=0i,tls=0i,first_byte=0i,download=0i 1705586982000000000
+`, w.String())
+}
+
+func Test_Output_Influx_Unsupported_Command(t *testing.T) {
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "mtr", Format: FormatInflux}, printer: NewPrinter(nil, w, w)}
+
+	err := viewer.OutputInflux(&globalping.Measurement{})
+	assert.EqualError(t, err, "unexpected command for influx output: mtr")
+}
@@ -1,8 +1,10 @@
 package view
 
+import "context"
+
 // Outputs the raw JSON for a measurement
-func (v *viewer) OutputJson(id string) error {
-	output, err := v.globalping.GetMeasurementRaw(id)
+func (v *viewer) OutputJson(ctx context.Context, id string) error {
+	output, err := v.globalping.GetMeasurementRaw(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package view
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"testing"
 
@@ -19,8 +20,8 @@ func Test_Output_Json(t *testing.T) {
 
 	gbMock := mocks.NewMockClient(ctrl)
 	measurement := createPingMeasurement(measurementID1)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
-	gbMock.EXPECT().GetMeasurementRaw(measurementID1).Times(1).Return(b, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurementRaw(gomock.Any(), measurementID1).Times(1).Return(b, nil)
 
 	w := new(bytes.Buffer)
 	viewer := NewViewer(
@@ -35,7 +36,7 @@ func Test_Output_Json(t *testing.T) {
 	)
 
 	m := &globalping.MeasurementCreate{}
-	err := viewer.Output(measurementID1, m)
+	err := viewer.Output(context.Background(), measurementID1, m)
 	assert.NoError(t, err)
 
 	assert.Equal(t, fmt.Sprintf(`{"fake": "results"}
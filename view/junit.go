@@ -0,0 +1,126 @@
+package view
+
+import (
+	"encoding/xml"
+	"os"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	ID         string          `xml:"id,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut *junitOutput  `xml:"system-out,omitempty"`
+}
+
+// The raw output is written as CDATA to keep it readable
+type junitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// Writes a JUnit XML report of the measurement to the file set with --junit, with a test case per probe.
+// Probes that didn't finish and assertion violations are reported as failures with the raw output attached.
+// Every measurement output by the viewer is added to the report as a separate test suite.
+func (v *viewer) OutputJUnit(id string, data *globalping.Measurement) error {
+	suite := junitTestSuite{
+		Name:      v.ctx.Cmd + " " + data.Target,
+		ID:        id,
+		Tests:     len(data.Results),
+		Timestamp: data.CreatedAt,
+		Properties: []junitProperty{
+			{Name: "measurement", Value: id},
+			{Name: "url", Value: ShareURL + id},
+		},
+		TestCases: make([]junitTestCase, len(data.Results)),
+	}
+	for i := range data.Results {
+		result := &data.Results[i]
+		rawOutput := strings.TrimSpace(result.Result.RawOutput)
+		testCase := junitTestCase{
+			Name:      getLocationText(result),
+			ClassName: "globalping." + v.ctx.Cmd,
+		}
+		switch result.Result.Status {
+		case globalping.StatusFinished:
+			if v.ctx.Assertions.IsSet() {
+				results, err := v.checkAssertions(&result.Result)
+				if err != nil {
+					return err
+				}
+				failed := []string{}
+				for j := range results {
+					if !results[j].Passed {
+						failed = append(failed, results[j].String())
+					}
+				}
+				if len(failed) > 0 {
+					testCase.Failure = &junitFailure{
+						Message: strings.Join(failed, "; "),
+						Type:    "assertion",
+						Text:    rawOutput,
+					}
+				}
+			}
+		case globalping.StatusInProgress:
+			testCase.Failure = &junitFailure{Message: "the probe timed out", Type: "timeout", Text: rawOutput}
+		default:
+			testCase.Failure = &junitFailure{
+				Message: "the probe result is " + string(result.Result.Status),
+				Type:    string(result.Result.Status),
+				Text:    rawOutput,
+			}
+		}
+		if testCase.Failure == nil {
+			testCase.SystemOut = &junitOutput{Text: rawOutput}
+		} else {
+			suite.Failures++
+		}
+		suite.TestCases[i] = testCase
+	}
+	v.junitSuites = append(v.junitSuites, suite)
+
+	report := junitTestSuites{
+		Name:   "globalping",
+		Suites: v.junitSuites,
+	}
+	for i := range v.junitSuites {
+		report.Tests += v.junitSuites[i].Tests
+		report.Failures += v.junitSuites[i].Failures
+	}
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(v.ctx.JUnitFile, append([]byte(xml.Header), append(b, '\n')...), 0644)
+}
@@ -0,0 +1,99 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_JUnit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Results = append(measurement.Results, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", City: "Miami", ASN: 789, Network: "Network 3"},
+		Result: globalping.ProbeResult{
+			Status:    globalping.StatusFinished,
+			RawOutput: "PING cdn.jsdelivr.net (1.2.3.4) 56(84) bytes of data.",
+			StatsRaw:  json.RawMessage(`{"min":92.1,"avg":95.5,"max":98.9,"total":3,"rcv":2,"drop":1,"loss":33.33}`),
+		},
+	}, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "EU", Country: "PL", City: "Warsaw", ASN: 456, Network: "Network 2"},
+		Result: globalping.ProbeResult{
+			Status:    globalping.StatusFailed,
+			RawOutput: "ping: cdn.jsdelivr.net: Name or service not known",
+		},
+	})
+	measurement2 := createPingMeasurement(measurementID2)
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID2).Times(1).Return(measurement2, nil)
+
+	maxLoss := 5.0
+	junitFile := filepath.Join(t.TempDir(), "report.xml")
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:        "ping",
+		CIMode:     true,
+		Format:     FormatCSV,
+		JUnitFile:  junitFile,
+		Assertions: Assertions{MaxLoss: &maxLoss},
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.ErrorIs(t, err, ErrAssertionFailed)
+
+	err = viewer.Output(context.Background(), measurementID2, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(junitFile)
+	assert.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="globalping" tests="4" failures="2">
+  <testsuite name="ping cdn.jsdelivr.net" id="`+measurementID1+`" tests="3" failures="2" timestamp="2024-01-18T14:09:41.250Z">
+    <properties>
+      <property name="measurement" value="`+measurementID1+`"></property>
+      <property name="url" value="https://www.jsdelivr.com/globalping?measurement=`+measurementID1+`"></property>
+    </properties>
+    <testcase name="Berlin, DE, EU, Deutsche Telekom AG (AS3320)" classname="globalping.ping">
+      <system-out><![CDATA[PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.
+64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=1 ttl=60 time=17.6 ms
+
+--- jsdelivr.map.fastly.net ping statistics ---
+1 packets transmitted, 1 received, 0% packet loss, time 1000ms
+rtt min/avg/max/mdev = 17.639/17.639/17.639/0.123 ms]]></system-out>
+    </testcase>
+    <testcase name="Miami, US, NA, Network 3 (AS789)" classname="globalping.ping">
+      <failure message="FAIL max-loss: 33.33% (expected &lt;= 5%)" type="assertion"><![CDATA[PING cdn.jsdelivr.net (1.2.3.4) 56(84) bytes of data.]]></failure>
+    </testcase>
+    <testcase name="Warsaw, PL, EU, Network 2 (AS456)" classname="globalping.ping">
+      <failure message="the probe result is failed" type="failed"><![CDATA[ping: cdn.jsdelivr.net: Name or service not known]]></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="ping cdn.jsdelivr.net" id="`+measurementID2+`" tests="1" failures="0" timestamp="2024-01-18T14:09:41.250Z">
+    <properties>
+      <property name="measurement" value="`+measurementID2+`"></property>
+      <property name="url" value="https://www.jsdelivr.com/globalping?measurement=`+measurementID2+`"></property>
+    </properties>
+    <testcase name="Berlin, DE, EU, Deutsche Telekom AG (AS3320)" classname="globalping.ping">
+      <system-out><![CDATA[PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.
+64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=1 ttl=60 time=17.6 ms
+
+--- jsdelivr.map.fastly.net ping statistics ---
+1 packets transmitted, 1 received, 0% packet loss, time 1000ms
+rtt min/avg/max/mdev = 17.639/17.639/17.639/0.123 ms]]></system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, string(b))
+}
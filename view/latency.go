@@ -18,6 +18,11 @@ func (v *viewer) OutputLatency(id string, data *globalping.Measurement) error {
 
 		v.printer.Println(v.getProbeInfo(&result))
 
+		if result.Result.Status == globalping.StatusInProgress {
+			v.printer.Println(v.latencyStatHeader("Status") + "timed out")
+			continue
+		}
+
 		switch v.ctx.Cmd {
 		case "ping":
 			stats, err := globalping.DecodePingStats(result.Result.StatsRaw)
@@ -44,6 +49,16 @@ func (v *viewer) OutputLatency(id string, data *globalping.Measurement) error {
 			v.printer.Println(v.latencyStatHeader("DNS") + fmt.Sprintf("%v ms", timings.DNS))
 			v.printer.Println(v.latencyStatHeader("TLS") + fmt.Sprintf("%v ms", timings.TLS))
 			v.printer.Println(v.latencyStatHeader("TCP") + fmt.Sprintf("%v ms", timings.TCP))
+		case "traceroute":
+			err := v.outputTracerouteLatency(&result.Result)
+			if err != nil {
+				return err
+			}
+		case "mtr":
+			err := v.outputMTRLatency(&result.Result)
+			if err != nil {
+				return err
+			}
 		default:
 			return errors.New("unexpected command for latency output: " + v.ctx.Cmd)
 		}
@@ -2,6 +2,7 @@ package view
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -49,7 +50,7 @@ func Test_Output_Latency_Ping_Not_CI(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	w := new(bytes.Buffer)
 	viewer := NewViewer(
@@ -62,7 +63,7 @@ func Test_Output_Latency_Ping_Not_CI(t *testing.T) {
 		gbMock,
 	)
 
-	err := viewer.Output(measurementID1, &globalping.MeasurementCreate{})
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
 	assert.NoError(t, err)
 
 	assert.Equal(t, "\033[1;38;2;23;212;167m> City (State), Country, Continent, Network (AS12345) (tag-1)\033[0m\n"+
@@ -99,7 +100,7 @@ func Test_Output_Latency_Ping_CI(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	w := new(bytes.Buffer)
 	viewer := NewViewer(
@@ -113,7 +114,7 @@ func Test_Output_Latency_Ping_CI(t *testing.T) {
 		gbMock,
 	)
 
-	err := viewer.Output(measurementID1, &globalping.MeasurementCreate{})
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
 	assert.NoError(t, err)
 
 	assert.Equal(t, `> City (State), Country, Continent, Network (AS12345)
@@ -148,7 +149,7 @@ func Test_Output_Latency_DNS_Not_CI(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	w := new(bytes.Buffer)
 	viewer := NewViewer(
@@ -161,7 +162,7 @@ func Test_Output_Latency_DNS_Not_CI(t *testing.T) {
 		gbMock,
 	)
 
-	err := viewer.Output(measurementID1, &globalping.MeasurementCreate{})
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
 	assert.NoError(t, err)
 
 	assert.Equal(t, "\033[1;38;2;23;212;167m> City (State), Country, Continent, Network (AS12345)\033[0m\n"+
@@ -192,7 +193,7 @@ func Test_Output_Latency_DNS_CI(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	w := new(bytes.Buffer)
 	viewer := NewViewer(
@@ -206,7 +207,7 @@ func Test_Output_Latency_DNS_CI(t *testing.T) {
 		gbMock,
 	)
 
-	err := viewer.Output(measurementID1, &globalping.MeasurementCreate{})
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
 	assert.NoError(t, err)
 
 	assert.Equal(t, `> City (State), Country, Continent, Network (AS12345)
@@ -239,7 +240,7 @@ func Test_Output_Latency_Http_Not_CI(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	w := new(bytes.Buffer)
 	viewer := NewViewer(
@@ -252,7 +253,7 @@ func Test_Output_Latency_Http_Not_CI(t *testing.T) {
 		gbMock,
 	)
 
-	err := viewer.Output(measurementID1, &globalping.MeasurementCreate{})
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
 	assert.NoError(t, err)
 
 	assert.Equal(t, "\033[1;38;2;23;212;167m> City (State), Country, Continent, Network (AS12345)\033[0m\n"+
@@ -288,7 +289,7 @@ func Test_Output_Latency_Http_CI(t *testing.T) {
 	}
 
 	gbMock := mocks.NewMockClient(ctrl)
-	gbMock.EXPECT().GetMeasurement(measurementID1).Times(1).Return(measurement, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
 
 	w := new(bytes.Buffer)
 	viewer := NewViewer(
@@ -302,7 +303,7 @@ func Test_Output_Latency_Http_CI(t *testing.T) {
 		gbMock,
 	)
 
-	err := viewer.Output(measurementID1, &globalping.MeasurementCreate{})
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
 	assert.NoError(t, err)
 
 	assert.Equal(t, `> City (State), Country, Continent, Network (AS12345)
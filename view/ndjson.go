@@ -0,0 +1,149 @@
+package view
+
+import (
+	"bufio"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// A packet received by a probe, output as a single line by the ndjson format
+type ndjsonPacket struct {
+	Type        string                  `json:"type"` // Always "packet"
+	Measurement string                  `json:"measurement"`
+	ProbeIndex  int                     `json:"probeIndex"` // The position of the probe in the results, stable across continuous measurements
+	Probe       globalping.ProbeDetails `json:"probe"`
+	Seq         int                     `json:"seq"` // The icmp_seq of the packet, starting at 1 in every measurement
+	RTT         float64                 `json:"rtt"`
+	TTL         int                     `json:"ttl"`
+	Timestamp   string                  `json:"timestamp"` // The time the packet was output
+}
+
+// The stats of a probe, output as a single line by the ndjson format once the measurement is finished
+type ndjsonStats struct {
+	Type        string                       `json:"type"` // Always "stats"
+	Measurement string                       `json:"measurement"`
+	ProbeIndex  int                          `json:"probeIndex"`
+	Probe       globalping.ProbeDetails      `json:"probe"`
+	Status      globalping.MeasurementStatus `json:"status"`
+	Stats       *globalping.PingStats        `json:"stats,omitempty"`
+	Timestamp   string                       `json:"timestamp"`
+}
+
+// Outputs a JSON object per line for every packet received by the probes since the previous call,
+// followed by a JSON object per probe with its stats once the measurement is finished.
+// Every line is written as soon as it's available, so the output can be consumed as a stream.
+func (v *viewer) OutputNDJSON(m *globalping.Measurement) error {
+	if v.ndjsonPackets == nil {
+		v.ndjsonPackets = make(map[string][]int)
+	}
+	printed := v.ndjsonPackets[m.ID]
+	if len(printed) < len(m.Results) {
+		printed = append(printed, make([]int, len(m.Results)-len(printed))...)
+	}
+	timestamp := v.time.Now().UTC().Format(time.RFC3339Nano)
+	for i := range m.Results {
+		result := &m.Results[i]
+		packets := parsePingPackets(result.Result.RawOutput)
+		for ; printed[i] < len(packets); printed[i]++ {
+			p := packets[printed[i]]
+			err := v.outputNDJSONLine(&ndjsonPacket{
+				Type:        "packet",
+				Measurement: m.ID,
+				ProbeIndex:  i,
+				Probe:       result.Probe,
+				Seq:         p.Seq,
+				RTT:         p.RTT,
+				TTL:         p.TTL,
+				Timestamp:   timestamp,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if m.Status == globalping.StatusInProgress {
+		v.ndjsonPackets[m.ID] = printed
+		return nil
+	}
+	delete(v.ndjsonPackets, m.ID)
+	for i := range m.Results {
+		result := &m.Results[i]
+		line := &ndjsonStats{
+			Type:        "stats",
+			Measurement: m.ID,
+			ProbeIndex:  i,
+			Probe:       result.Probe,
+			Status:      result.Result.Status,
+			Timestamp:   timestamp,
+		}
+		if len(result.Result.StatsRaw) > 0 {
+			stats, err := globalping.DecodePingStats(result.Result.StatsRaw)
+			if err != nil {
+				return err
+			}
+			line.Stats = stats
+		}
+		err := v.outputNDJSONLine(line)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *viewer) outputNDJSONLine(line any) error {
+	b, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	v.printer.Println(string(b))
+	return nil
+}
+
+type pingPacket struct {
+	Seq int
+	RTT float64
+	TTL int
+}
+
+// Returns the packets received by a probe, parsed from the replies in the raw output of ping,
+// e.g. "64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=1 ttl=60 time=17.6 ms"
+func parsePingPackets(rawOutput string) []pingPacket {
+	packets := []pingPacket{}
+	scanner := bufio.NewScanner(strings.NewReader(rawOutput))
+	scanner.Scan() // skip the header
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) == 0 {
+			// The summary follows an empty line
+			break
+		}
+		words := strings.Split(line, " ")
+		if len(words) < 3 || words[1] != "bytes" || words[2] != "from" {
+			continue
+		}
+		p := pingPacket{Seq: -1}
+		for _, word := range words {
+			key, value, ok := strings.Cut(word, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "icmp_seq":
+				p.Seq, _ = strconv.Atoi(value)
+			case "ttl":
+				p.TTL, _ = strconv.Atoi(value)
+			case "time":
+				p.RTT, _ = strconv.ParseFloat(value, 64)
+			}
+		}
+		if p.Seq != -1 {
+			packets = append(packets, p)
+		}
+	}
+	return packets
+}
@@ -0,0 +1,77 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_OutputInfinite_NDJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).Times(3)
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "ping", Format: FormatNDJSON}, printer: NewPrinter(nil, w, w), time: timeMock}
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Status = globalping.StatusInProgress
+	measurement.Results[0].Result.Status = globalping.StatusInProgress
+	measurement.Results[0].Result.RawOutput = `PING cdn.jsdelivr.net (151.101.1.229) 56(84) bytes of data.
+64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=1 ttl=60 time=17.6 ms`
+	measurement.Results[0].Result.StatsRaw = nil
+
+	err := viewer.OutputInfinite(context.Background(), measurement)
+	assert.NoError(t, err)
+
+	probe := `"probeIndex":0,"probe":{"continent":"EU","region":"Western Europe","country":"DE","city":"Berlin","asn":3320,"network":"Deutsche Telekom AG","tags":["eyeball-network"]}`
+	packet1 := `{"type":"packet","measurement":"` + measurementID1 + `",` + probe + `,"seq":1,"rtt":17.6,"ttl":60,"timestamp":"2024-01-01T00:00:00Z"}` + "\n"
+	assert.Equal(t, packet1, w.String())
+
+	// Packets already output are skipped
+	measurement.Results[0].Result.RawOutput += `
+no answer yet for icmp_seq=2
+64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=3 ttl=60 time=18.25 ms`
+	err = viewer.OutputInfinite(context.Background(), measurement)
+	assert.NoError(t, err)
+
+	packet3 := `{"type":"packet","measurement":"` + measurementID1 + `",` + probe + `,"seq":3,"rtt":18.25,"ttl":60,"timestamp":"2024-01-01T00:00:00Z"}` + "\n"
+	assert.Equal(t, packet1+packet3, w.String())
+
+	measurement.Status = globalping.StatusFinished
+	measurement.Results[0].Result.Status = globalping.StatusFinished
+	measurement.Results[0].Result.RawOutput += `
+
+--- cdn.jsdelivr.net ping statistics ---
+3 packets transmitted, 2 received, 33.3333% packet loss, time 2002ms
+rtt min/avg/max/mdev = 17.6/17.925/18.25/0.325 ms`
+	measurement.Results[0].Result.StatsRaw = []byte(`{"min":17.6,"avg":17.925,"max":18.25,"total":3,"rcv":2,"drop":1,"loss":33.33,"mdev":0.325}`)
+	err = viewer.OutputInfinite(context.Background(), measurement)
+	assert.NoError(t, err)
+
+	stats := `{"type":"stats","measurement":"` + measurementID1 + `",` + probe + `,"status":"finished","stats":{"min":17.6,"avg":17.925,"max":18.25,"total":3,"rcv":2,"drop":1,"loss":33.33,"mdev":0.325},"timestamp":"2024-01-01T00:00:00Z"}` + "\n"
+	assert.Equal(t, packet1+packet3+stats, w.String())
+	assert.Empty(t, viewer.ndjsonPackets)
+}
+
+func Test_ParsePingPackets(t *testing.T) {
+	packets := parsePingPackets(`PING cdn.jsdelivr.net (151.101.1.229) 56(84) bytes of data.
+64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=1 ttl=60 time=17.6 ms
+no answer yet for icmp_seq=2
+64 bytes from lhr25s34-in-f14.1e100.net (142.250.180.14): icmp_seq=3 ttl=117 time=1.05 ms
+
+--- cdn.jsdelivr.net ping statistics ---
+3 packets transmitted, 2 received, 33.3333% packet loss, time 2002ms`)
+
+	assert.Equal(t, []pingPacket{
+		{Seq: 1, RTT: 17.6, TTL: 60},
+		{Seq: 3, RTT: 1.05, TTL: 117},
+	}, packets)
+}
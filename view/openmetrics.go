@@ -0,0 +1,188 @@
+package view
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// A sample of an OpenMetrics metric family, with the labels of its probe
+type openMetricsSample struct {
+	probe  int
+	labels [][2]string // Additional labels, e.g. the phase of an http timing
+	value  float64
+}
+
+type openMetricsFamily struct {
+	name    string
+	help    string
+	samples []openMetricsSample
+}
+
+// Outputs the results of a measurement as a complete OpenMetrics exposition ending with "# EOF", one sample per probe and metric.
+// Probes which didn't finish only report a success of 0. If an output file is set, it's atomically replaced by the exposition,
+// so a node_exporter textfile collector or another scraper always reads the results of the last measurement.
+func (v *viewer) OutputOpenMetrics(data *globalping.Measurement) error {
+	var decode func(result *globalping.ProbeResult, probe int, families map[string]*openMetricsFamily) error
+	var names []string
+	switch v.ctx.Cmd {
+	case "ping":
+		decode = openMetricsPingSamples
+		names = []string{"packets_sent", "packets_received", "packet_loss_percent", "rtt_min_seconds", "rtt_avg_seconds", "rtt_max_seconds", "rtt_mdev_seconds"}
+	case "dns":
+		decode = openMetricsDNSSamples
+		names = []string{"answers", "duration_seconds"}
+	case "http":
+		decode = openMetricsHTTPSamples
+		names = []string{"status_code", "duration_seconds", "phase_duration_seconds"}
+	default:
+		return errors.New("unexpected command for openmetrics output: " + v.ctx.Cmd)
+	}
+
+	families := map[string]*openMetricsFamily{}
+	order := []*openMetricsFamily{{name: "success", help: "Whether the probe finished the measurement."}}
+	families["success"] = order[0]
+	for _, name := range names {
+		families[name] = &openMetricsFamily{name: name, help: openMetricsHelp[name]}
+		order = append(order, families[name])
+	}
+	for i := range data.Results {
+		result := &data.Results[i].Result
+		if result.Status != globalping.StatusFinished {
+			families["success"].add(i, 0)
+			continue
+		}
+		families["success"].add(i, 1)
+		err := decode(result, i, families)
+		if err != nil {
+			return err
+		}
+	}
+
+	labels := make([][][2]string, len(data.Results))
+	for i := range data.Results {
+		probe := &data.Results[i].Probe
+		labels[i] = [][2]string{
+			{"target", data.Target},
+			{"probe", strconv.Itoa(i)},
+			{"continent", probe.Continent},
+			{"country", probe.Country},
+			{"city", probe.City},
+			{"asn", strconv.Itoa(probe.ASN)},
+			{"network", probe.Network},
+		}
+	}
+	b := &bytes.Buffer{}
+	for _, f := range order {
+		name := "globalping_" + v.ctx.Cmd + "_" + f.name
+		fmt.Fprintf(b, "# HELP %s %s\n", name, f.help)
+		fmt.Fprintf(b, "# TYPE %s gauge\n", name)
+		for _, s := range f.samples {
+			fmt.Fprintf(b, "%s%s %s\n", name, formatMetricLabels(append(labels[s.probe], s.labels...)), strconv.FormatFloat(s.value, 'g', -1, 64))
+		}
+	}
+	b.WriteString("# EOF\n")
+
+	if v.ctx.OutputFile != "" {
+		return replaceFile(v.ctx.OutputFile, b.Bytes())
+	}
+	v.printer.Print(b.String())
+	return nil
+}
+
+var openMetricsHelp = map[string]string{
+	"packets_sent":           "Number of packets sent by the probe.",
+	"packets_received":       "Number of packets received by the probe.",
+	"packet_loss_percent":    "Percentage of packets lost.",
+	"rtt_min_seconds":        "Minimum round-trip time.",
+	"rtt_avg_seconds":        "Average round-trip time.",
+	"rtt_max_seconds":        "Maximum round-trip time.",
+	"rtt_mdev_seconds":       "Mean deviation of the round-trip time.",
+	"answers":                "Number of answers received by the probe.",
+	"status_code":            "Status code of the response.",
+	"duration_seconds":       "Total time of the request.",
+	"phase_duration_seconds": "Time of every phase of the request.",
+}
+
+func (f *openMetricsFamily) add(probe int, value float64, labels ...[2]string) {
+	f.samples = append(f.samples, openMetricsSample{probe: probe, labels: labels, value: value})
+}
+
+func openMetricsPingSamples(result *globalping.ProbeResult, probe int, families map[string]*openMetricsFamily) error {
+	stats, err := globalping.DecodePingStats(result.StatsRaw)
+	if err != nil {
+		return err
+	}
+	families["packets_sent"].add(probe, float64(stats.Total))
+	families["packets_received"].add(probe, float64(stats.Rcv))
+	families["packet_loss_percent"].add(probe, stats.Loss)
+	// Without replies, the RTT stats are not measured
+	if stats.Rcv > 0 {
+		families["rtt_min_seconds"].add(probe, msToSeconds(stats.Min))
+		families["rtt_avg_seconds"].add(probe, msToSeconds(stats.Avg))
+		families["rtt_max_seconds"].add(probe, msToSeconds(stats.Max))
+		families["rtt_mdev_seconds"].add(probe, msToSeconds(stats.Mdev))
+	}
+	return nil
+}
+
+func openMetricsDNSSamples(result *globalping.ProbeResult, probe int, families map[string]*openMetricsFamily) error {
+	summary, err := summarizeDNSResult(result)
+	if err != nil {
+		return err
+	}
+	families["answers"].add(probe, float64(len(summary.Answers)))
+	families["duration_seconds"].add(probe, msToSeconds(summary.Total))
+	return nil
+}
+
+func openMetricsHTTPSamples(result *globalping.ProbeResult, probe int, families map[string]*openMetricsFamily) error {
+	timings, err := globalping.DecodeHTTPTimings(result.TimingsRaw)
+	if err != nil {
+		return err
+	}
+	families["status_code"].add(probe, float64(result.StatusCode))
+	families["duration_seconds"].add(probe, msToSeconds(float64(timings.Total)))
+	for _, phase := range []struct {
+		name string
+		ms   int
+	}{
+		{"dns", timings.DNS},
+		{"tcp", timings.TCP},
+		{"tls", timings.TLS},
+		{"first_byte", timings.FirstByte},
+		{"download", timings.Download},
+	} {
+		families["phase_duration_seconds"].add(probe, msToSeconds(float64(phase.ms)), [2]string{"phase", phase.name})
+	}
+	return nil
+}
+
+// Replaces the file by writing to a temporary file in the same directory first, so readers never see a partial file
+func replaceFile(path string, body []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write the output file: %w", err)
+	}
+	_, err = f.Write(body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// The temporary file is only readable by the owner
+		err = os.Chmod(f.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to write the output file: %w", err)
+	}
+	return nil
+}
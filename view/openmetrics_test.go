@@ -0,0 +1,203 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_OpenMetrics_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Results = append(measurement.Results, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", State: "NY", City: "New York", ASN: 567, Network: `Network "1"`},
+		Result: globalping.ProbeResult{
+			Status:   globalping.StatusFinished,
+			StatsRaw: json.RawMessage(`{"min":null,"avg":null,"max":null,"mdev":null,"total":3,"rcv":0,"drop":3,"loss":100}`),
+		},
+	}, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", State: "FL", City: "Miami", ASN: 789, Network: "Network 3"},
+		Result: globalping.ProbeResult{
+			Status: globalping.StatusFailed,
+		},
+	})
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:    "ping",
+		Format: FormatOpenMetrics,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+
+	berlin := `{target="cdn.jsdelivr.net",probe="0",continent="EU",country="DE",city="Berlin",asn="3320",network="Deutsche Telekom AG"}`
+	newYork := `{target="cdn.jsdelivr.net",probe="1",continent="NA",country="US",city="New York",asn="567",network="Network \"1\""}`
+	miami := `{target="cdn.jsdelivr.net",probe="2",continent="NA",country="US",city="Miami",asn="789",network="Network 3"}`
+	assert.Equal(t, `# HELP globalping_ping_success Whether the probe finished the measurement.
+# TYPE globalping_ping_success gauge
+globalping_ping_success`+berlin+` 1
+globalping_ping_success`+newYork+` 1
+globalping_ping_success`+miami+` 0
+# HELP globalping_ping_packets_sent Number of packets sent by the probe.
+# TYPE globalping_ping_packets_sent gauge
+globalping_ping_packets_sent`+berlin+` 1
+globalping_ping_packets_sent`+newYork+` 3
+# HELP globalping_ping_packets_received Number of packets received by the probe.
+# TYPE globalping_ping_packets_received gauge
+globalping_ping_packets_received`+berlin+` 1
+globalping_ping_packets_received`+newYork+` 0
+# HELP globalping_ping_packet_loss_percent Percentage of packets lost.
+# TYPE globalping_ping_packet_loss_percent gauge
+globalping_ping_packet_loss_percent`+berlin+` 0
+globalping_ping_packet_loss_percent`+newYork+` 100
+# HELP globalping_ping_rtt_min_seconds Minimum round-trip time.
+# TYPE globalping_ping_rtt_min_seconds gauge
+globalping_ping_rtt_min_seconds`+berlin+` 0.017639
+# HELP globalping_ping_rtt_avg_seconds Average round-trip time.
+# TYPE globalping_ping_rtt_avg_seconds gauge
+globalping_ping_rtt_avg_seconds`+berlin+` 0.017639
+# HELP globalping_ping_rtt_max_seconds Maximum round-trip time.
+# TYPE globalping_ping_rtt_max_seconds gauge
+globalping_ping_rtt_max_seconds`+berlin+` 0.017639
+# HELP globalping_ping_rtt_mdev_seconds Mean deviation of the round-trip time.
+# TYPE globalping_ping_rtt_mdev_seconds gauge
+globalping_ping_rtt_mdev_seconds`+berlin+` 0
+# EOF
+`, w.String())
+}
+
+func Test_Output_OpenMetrics_DNS_HTTP(t *testing.T) {
+	probe := globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"}
+	labels := `{target="jsdelivr.com",probe="0",continent="EU",country="DE",city="Berlin",asn="123",network="Network 1"}`
+	dns := &globalping.Measurement{
+		ID:     measurementID1,
+		Target: "jsdelivr.com",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: probe,
+			Result: globalping.ProbeResult{
+				Status:         globalping.StatusFinished,
+				StatusCodeName: "NOERROR",
+				AnswersRaw:     json.RawMessage(`[{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"1.2.3.4"},{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"5.6.7.8"}]`),
+				TimingsRaw:     json.RawMessage(`{"total":15}`),
+			},
+		}},
+	}
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "dns", Format: FormatOpenMetrics}, printer: NewPrinter(nil, w, w)}
+	err := viewer.OutputOpenMetrics(dns)
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP globalping_dns_success Whether the probe finished the measurement.
+# TYPE globalping_dns_success gauge
+globalping_dns_success`+labels+` 1
+# HELP globalping_dns_answers Number of answers received by the probe.
+# TYPE globalping_dns_answers gauge
+globalping_dns_answers`+labels+` 2
+# HELP globalping_dns_duration_seconds Total time of the request.
+# TYPE globalping_dns_duration_seconds gauge
+globalping_dns_duration_seconds`+labels+` 0.015
+# EOF
+`, w.String())
+
+	http := &globalping.Measurement{
+		ID:     measurementID2,
+		Target: "jsdelivr.com",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: probe,
+			Result: globalping.ProbeResult{
+				Status:     globalping.StatusFinished,
+				StatusCode: 200,
+				TimingsRaw: json.RawMessage(`{"total":583,"download":18,"firstByte":450,"dns":24,"tls":70,"tcp":19}`),
+			},
+		}},
+	}
+	w.Reset()
+	viewer.ctx.Cmd = "http"
+	err = viewer.OutputOpenMetrics(http)
+	assert.NoError(t, err)
+	phase := func(name string) string {
+		return labels[:len(labels)-1] + `,phase="` + name + `"}`
+	}
+	assert.Equal(t, `# HELP globalping_http_success Whether the probe finished the measurement.
+# TYPE globalping_http_success gauge
+globalping_http_success`+labels+` 1
+# HELP globalping_http_status_code Status code of the response.
+# TYPE globalping_http_status_code gauge
+globalping_http_status_code`+labels+` 200
+# HELP globalping_http_duration_seconds Total time of the request.
+# TYPE globalping_http_duration_seconds gauge
+globalping_http_duration_seconds`+labels+` 0.583
+# HELP globalping_http_phase_duration_seconds Time of every phase of the request.
+# TYPE globalping_http_phase_duration_seconds gauge
+globalping_http_phase_duration_seconds`+phase("dns")+` 0.024
+globalping_http_phase_duration_seconds`+phase("tcp")+` 0.019
+globalping_http_phase_duration_seconds`+phase("tls")+` 0.07
+globalping_http_phase_duration_seconds`+phase("first_byte")+` 0.45
+globalping_http_phase_duration_seconds`+phase("download")+` 0.018
+# EOF
+`, w.String())
+}
+
+func Test_Output_OpenMetrics_File(t *testing.T) {
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "globalping.prom")
+	err := os.WriteFile(outputFile, []byte("previous results\n"), 0600)
+	assert.NoError(t, err)
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "ping", Format: FormatOpenMetrics, OutputFile: outputFile}, printer: NewPrinter(nil, w, w)}
+
+	measurement := createPingMeasurement(measurementID1)
+	err = viewer.OutputOpenMetrics(measurement)
+	assert.NoError(t, err)
+	measurement.Results[0].Result.Status = globalping.StatusFailed
+	err = viewer.OutputOpenMetrics(measurement)
+	assert.NoError(t, err)
+
+	// The file is replaced by the exposition of the last measurement
+	assert.Equal(t, "", w.String())
+	b, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP globalping_ping_success Whether the probe finished the measurement.
+# TYPE globalping_ping_success gauge
+globalping_ping_success{target="cdn.jsdelivr.net",probe="0",continent="EU",country="DE",city="Berlin",asn="3320",network="Deutsche Telekom AG"} 0
+# HELP globalping_ping_packets_sent Number of packets sent by the probe.
+# TYPE globalping_ping_packets_sent gauge
+# HELP globalping_ping_packets_received Number of packets received by the probe.
+# TYPE globalping_ping_packets_received gauge
+# HELP globalping_ping_packet_loss_percent Percentage of packets lost.
+# TYPE globalping_ping_packet_loss_percent gauge
+# HELP globalping_ping_rtt_min_seconds Minimum round-trip time.
+# TYPE globalping_ping_rtt_min_seconds gauge
+# HELP globalping_ping_rtt_avg_seconds Average round-trip time.
+# TYPE globalping_ping_rtt_avg_seconds gauge
+# HELP globalping_ping_rtt_max_seconds Maximum round-trip time.
+# TYPE globalping_ping_rtt_max_seconds gauge
+# HELP globalping_ping_rtt_mdev_seconds Mean deviation of the round-trip time.
+# TYPE globalping_ping_rtt_mdev_seconds gauge
+# EOF
+`, string(b))
+
+	fi, err := os.Stat(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), fi.Mode().Perm())
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
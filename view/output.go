@@ -1,73 +1,95 @@
 package view
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/utils"
 	"github.com/mattn/go-runewidth"
 )
 
 var ShareURL = "https://www.jsdelivr.com/globalping?measurement="
 
-func (v *viewer) Output(id string, m *globalping.MeasurementCreate) error {
+var ErrTimeout = errors.New("timeout reached before the measurement finished")
+
+func (v *viewer) Output(ctx context.Context, id string, m *globalping.MeasurementCreate) error {
+	data, err := v.output(ctx, id, m)
+	if data == nil {
+		return err
+	}
+	if err == nil && v.ctx.Assertions.IsSet() {
+		err = v.OutputAssertions(data)
+	}
+	// The report is also written if the measurement timed out or failed the assertions
+	if v.ctx.JUnitFile != "" {
+		junitErr := v.OutputJUnit(id, data)
+		if junitErr != nil {
+			return junitErr
+		}
+	}
+	return err
+}
+
+// Outputs the measurement and returns its final state
+func (v *viewer) output(ctx context.Context, id string, m *globalping.MeasurementCreate) (*globalping.Measurement, error) {
 	// Wait for first result to arrive from a probe before starting display (can be in-progress)
-	data, err := v.globalping.GetMeasurement(id)
+	data, err := v.globalping.GetMeasurement(ctx, id)
 	if err != nil {
-		return err
+		return nil, v.timeoutOrErr(err)
 	}
 	// Probe may not have started yet
 	for len(data.Results) == 0 {
-		time.Sleep(v.ctx.APIMinInterval)
-		data, err = v.globalping.GetMeasurement(id)
+		data, err = v.refresh(ctx, id)
 		if err != nil {
-			return err
+			return nil, v.timeoutOrErr(err)
 		}
 	}
 
-	if v.ctx.CIMode || v.ctx.ToJSON || v.ctx.ToLatency {
+	f := v.selectedFormat()
+	if f != nil || v.ctx.CIMode {
 		// Poll API until the measurement is complete
 		for data.Status == globalping.StatusInProgress {
-			time.Sleep(v.ctx.APIMinInterval)
-			data, err = v.globalping.GetMeasurement(id)
+			next, err := v.refresh(ctx, id)
 			if err != nil {
-				return err
+				if errors.Is(err, context.DeadlineExceeded) {
+					return data, v.outputTimeout(ctx, id, data, m)
+				}
+				return nil, err
 			}
+			data = next
 		}
 
-		if v.ctx.ToLatency {
-			return v.OutputLatency(id, data)
+		if f != nil {
+			return data, f.output(v, ctx, id, data)
 		}
 
-		if v.ctx.ToJSON {
-			return v.OutputJson(id)
-		}
-
-		if v.ctx.CIMode {
-			v.outputDefault(id, data, m)
-			return nil
-		}
+		v.outputDefault(id, data, m)
+		return data, nil
 	}
 
-	return v.liveView(id, data, m)
+	return v.liveView(ctx, id, data, m)
 }
 
-func (v *viewer) liveView(id string, data *globalping.Measurement, m *globalping.MeasurementCreate) error {
-	var err error
-
+func (v *viewer) liveView(ctx context.Context, id string, data *globalping.Measurement, m *globalping.MeasurementCreate) (*globalping.Measurement, error) {
 	w, h := v.printer.GetSize()
 
 	output := &strings.Builder{}
 
 	// Poll API until the measurement is complete
 	for data.Status == globalping.StatusInProgress {
-		time.Sleep(v.ctx.APIMinInterval)
-		data, err = v.globalping.GetMeasurement(id)
+		next, err := v.refresh(ctx, id)
 		if err != nil {
-			return fmt.Errorf("failed to get data: %v", err)
+			v.printer.AreaClear()
+			if errors.Is(err, context.DeadlineExceeded) {
+				return data, v.outputTimeout(ctx, id, data, m)
+			}
+			return nil, fmt.Errorf("failed to get data: %w", err)
 		}
+		data = next
 
 		output.Reset()
 
@@ -89,7 +111,37 @@ func (v *viewer) liveView(id string, data *globalping.Measurement, m *globalping
 	v.printer.AreaClear()
 
 	v.outputDefault(id, data, m)
-	return nil
+	return data, nil
+}
+
+// Waits for the minimum API interval and fetches the latest state of the measurement
+func (v *viewer) refresh(ctx context.Context, id string) (*globalping.Measurement, error) {
+	err := utils.Sleep(ctx, v.ctx.APIMinInterval)
+	if err != nil {
+		return nil, err
+	}
+	return v.globalping.GetMeasurement(ctx, id)
+}
+
+// Outputs the results received before the timeout was reached
+func (v *viewer) outputTimeout(ctx context.Context, id string, data *globalping.Measurement, m *globalping.MeasurementCreate) error {
+	if f := v.selectedFormat(); f != nil {
+		err := f.output(v, ctx, id, data)
+		if err != nil {
+			return err
+		}
+	} else {
+		v.outputDefault(id, data, m)
+	}
+	return ErrTimeout
+}
+
+// Maps a context deadline error to ErrTimeout
+func (v *viewer) timeoutOrErr(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+	return err
 }
 
 // Used to trim the output to fit the terminal in live view
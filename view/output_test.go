@@ -1,11 +1,16 @@
 package view
 
 import (
+	"bytes"
+	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 )
 
 var (
@@ -92,3 +97,87 @@ some text f`
 
 	assert.Equal(t, expectedRes, *res)
 }
+
+func Test_Output_Canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Status = globalping.StatusInProgress
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(ctx, measurementID1).Times(1).DoAndReturn(
+		func(_ context.Context, _ string) (*globalping.Measurement, error) {
+			cancel()
+			return measurement, nil
+		})
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:            "ping",
+		CIMode:         true,
+		APIMinInterval: time.Hour,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(ctx, measurementID1, &globalping.MeasurementCreate{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "", w.String())
+}
+
+func Test_Output_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Status = globalping.StatusInProgress
+	measurement.Results[0].Result.Status = globalping.StatusInProgress
+	measurement.Results[0].Result.RawOutput = "PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data."
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(ctx, measurementID1).Times(1).Return(measurement, nil)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:            "ping",
+		CIMode:         true,
+		APIMinInterval: time.Hour,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(ctx, measurementID1, &globalping.MeasurementCreate{})
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Equal(t, `> Berlin, DE, EU, Deutsche Telekom AG (AS3320) (timed out)
+PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.
+`, w.String())
+}
+
+func Test_Output_Timeout_Latency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Status = globalping.StatusInProgress
+	measurement.Results[0].Result.Status = globalping.StatusInProgress
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(ctx, measurementID1).Times(1).Return(measurement, nil)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:            "ping",
+		CIMode:         true,
+		ToLatency:      true,
+		APIMinInterval: time.Hour,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(ctx, measurementID1, &globalping.MeasurementCreate{})
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Equal(t, "> Berlin, DE, EU, Deutsche Telekom AG (AS3320)\nStatus: timed out\n\n", w.String())
+}
@@ -0,0 +1,104 @@
+package view
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// The probes matching a location, with the number of probes per continent and country
+type ProbesResult struct {
+	Probes     []globalping.ProbeDetails `json:"probes"`
+	Continents map[string]int            `json:"continents"`
+	Countries  map[string]int            `json:"countries"`
+	Total      int                       `json:"total"`
+}
+
+// Returns the probes sorted by continent, country and city, with their counts
+func NewProbesResult(probes []globalping.ProbeDetails) *ProbesResult {
+	res := &ProbesResult{
+		Probes:     slices.Clone(probes),
+		Continents: map[string]int{},
+		Countries:  map[string]int{},
+		Total:      len(probes),
+	}
+	if res.Probes == nil {
+		res.Probes = []globalping.ProbeDetails{}
+	}
+	slices.SortStableFunc(res.Probes, func(a, b globalping.ProbeDetails) int {
+		if c := strings.Compare(a.Continent, b.Continent); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Country, b.Country); c != 0 {
+			return c
+		}
+		return strings.Compare(a.City, b.City)
+	})
+	for i := range res.Probes {
+		res.Continents[res.Probes[i].Continent]++
+		res.Countries[res.Probes[i].Country]++
+	}
+	return res
+}
+
+// Outputs the probes as a table followed by the number of probes per continent and country, or as JSON
+func OutputProbes(ctx *Context, printer *Printer, res *ProbesResult) error {
+	v := &viewer{ctx: ctx, printer: printer}
+	if ctx.ToJSON {
+		b, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return err
+		}
+		v.printer.Println(string(b))
+		return nil
+	}
+
+	if len(res.Probes) == 0 {
+		v.printer.Println("No probes found")
+		return nil
+	}
+
+	rows := [][]string{{"City", "Country", "Continent", "ASN", "Network", "Tags"}}
+	for i := range res.Probes {
+		p := &res.Probes[i]
+		city := p.City
+		if p.State != "" {
+			city += " (" + p.State + ")"
+		}
+		rows = append(rows, []string{city, p.Country, p.Continent, "AS" + strconv.Itoa(p.ASN), p.Network, strings.Join(p.Tags, ", ")})
+	}
+	v.printer.Print(v.formatTable(rows))
+	v.printer.Println()
+	v.printer.Print(v.formatTable(countRows("Continent", res.Continents)))
+	v.printer.Println()
+	v.printer.Print(v.formatTable(countRows("Country", res.Countries)))
+	v.printer.Println()
+	if res.Total == 1 {
+		v.printer.Println("Total: 1 probe")
+	} else {
+		v.printer.Printf("Total: %d probes\n", res.Total)
+	}
+	return nil
+}
+
+// Returns the rows of a count table, sorted by count then name
+func countRows(title string, counts map[string]int) [][]string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	rows := [][]string{{title, "Probes"}}
+	for _, name := range names {
+		rows = append(rows, []string{name, strconv.Itoa(counts[name])})
+	}
+	return rows
+}
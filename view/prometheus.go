@@ -0,0 +1,156 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// Holds the latest stats of a continuous ping measurement in the Prometheus text exposition format.
+// The stats are updated by the viewer and can be scraped or pushed concurrently.
+type Metrics struct {
+	mu   sync.RWMutex
+	body []byte
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Serves the latest metrics
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metricsContentType)
+	w.Write(m.Bytes())
+}
+
+// Pushes the latest metrics to a Pushgateway compatible endpoint, replacing the previously pushed ones.
+// If the url doesn't contain a grouping key, the metrics are pushed under the "globalping" job.
+func (m *Metrics) Push(ctx context.Context, url string) error {
+	url = strings.TrimSuffix(url, "/")
+	if !strings.Contains(url, "/metrics/job/") {
+		url += "/metrics/job/globalping"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(m.Bytes()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", metricsContentType)
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to push metrics: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Returns a copy of the latest metrics
+func (m *Metrics) Bytes() []byte {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]byte{}, m.body...)
+}
+
+func (m *Metrics) update(body []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.body = body
+}
+
+type metricFamily struct {
+	name  string
+	help  string
+	kind  string
+	value func(stats *MeasurementStats) (float64, bool)
+}
+
+var pingMetricFamilies = []metricFamily{
+	{"globalping_ping_packets_sent_total", "Number of packets sent by the probe.", "counter", func(s *MeasurementStats) (float64, bool) {
+		return float64(s.Sent), true
+	}},
+	{"globalping_ping_packets_received_total", "Number of packets received by the probe.", "counter", func(s *MeasurementStats) (float64, bool) {
+		return float64(s.Rcv), true
+	}},
+	{"globalping_ping_packet_loss_percent", "Percentage of packets lost.", "gauge", func(s *MeasurementStats) (float64, bool) {
+		return s.Loss, true
+	}},
+	{"globalping_ping_rtt_last_seconds", "Round-trip time of the last received packet.", "gauge", func(s *MeasurementStats) (float64, bool) {
+		return msToSeconds(s.Last), s.Rcv > 0
+	}},
+	{"globalping_ping_rtt_min_seconds", "Minimum round-trip time.", "gauge", func(s *MeasurementStats) (float64, bool) {
+		return msToSeconds(s.Min), s.Rcv > 0
+	}},
+	{"globalping_ping_rtt_avg_seconds", "Average round-trip time.", "gauge", func(s *MeasurementStats) (float64, bool) {
+		return msToSeconds(s.Avg), s.Rcv > 0
+	}},
+	{"globalping_ping_rtt_max_seconds", "Maximum round-trip time.", "gauge", func(s *MeasurementStats) (float64, bool) {
+		return msToSeconds(s.Max), s.Rcv > 0
+	}},
+	{"globalping_ping_rtt_mdev_seconds", "Mean deviation of the round-trip time.", "gauge", func(s *MeasurementStats) (float64, bool) {
+		return msToSeconds(s.Mdev), s.Rcv > 0
+	}},
+}
+
+// Updates the metrics with the aggregated stats of all probes, including the measurements still in progress
+func (v *viewer) updateMetrics(m *globalping.Measurement) {
+	if v.ctx.Metrics == nil || len(v.ctx.AggregatedStats) != len(m.Results) {
+		return
+	}
+	stats := make([]*MeasurementStats, len(m.Results))
+	labels := make([]string, len(m.Results))
+	for i := range m.Results {
+		stats[i] = v.aggregateConcurentStats(v.ctx.AggregatedStats[i], i, "")
+		probe := &m.Results[i].Probe
+		labels[i] = formatMetricLabels([][2]string{
+			{"target", v.ctx.Target},
+			{"probe", strconv.Itoa(i)},
+			{"continent", probe.Continent},
+			{"country", probe.Country},
+			{"city", probe.City},
+			{"asn", strconv.Itoa(probe.ASN)},
+			{"network", probe.Network},
+		})
+	}
+
+	b := &bytes.Buffer{}
+	for _, f := range pingMetricFamilies {
+		fmt.Fprintf(b, "# HELP %s %s\n", f.name, f.help)
+		fmt.Fprintf(b, "# TYPE %s %s\n", f.name, f.kind)
+		for i := range stats {
+			value, ok := f.value(stats[i])
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(b, "%s%s %s\n", f.name, labels[i], strconv.FormatFloat(value, 'g', -1, 64))
+		}
+	}
+	v.ctx.Metrics.update(b.Bytes())
+}
+
+// Converts a duration in milliseconds to seconds, rounded to microseconds
+func msToSeconds(ms float64) float64 {
+	return math.Round(ms*1000) / 1e6
+}
+
+var metricLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatMetricLabels(labels [][2]string) string {
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = l[0] + `="` + metricLabelReplacer.Replace(l[1]) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
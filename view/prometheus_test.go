@@ -0,0 +1,126 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OutputInfinite_Metrics(t *testing.T) {
+	measurement := createPingMeasurement_MultipleProbes(measurementID1)
+	measurement.Results[2].Result.RawOutput = `PING  (104.16.88.20) 56(84) bytes of data.
+
+---  ping statistics ---
+1 packets transmitted, 0 received, 100% packet loss, time 0ms`
+
+	ctx := createDefaultContext("ping")
+	ctx.Target = "cdn.jsdelivr.net"
+	ctx.Metrics = NewMetrics()
+	w := new(bytes.Buffer)
+	v := NewViewer(ctx, NewPrinter(nil, w, w), nil, nil)
+	err := v.OutputInfinite(context.Background(), measurement)
+	assert.NoError(t, err)
+
+	london := `{target="cdn.jsdelivr.net",probe="0",continent="EU",country="GB",city="London",asn="0",network="OVH SAS"}`
+	falkenstein := `{target="cdn.jsdelivr.net",probe="1",continent="EU",country="DE",city="Falkenstein",asn="0",network="Hetzner Online GmbH"}`
+	nuremberg := `{target="cdn.jsdelivr.net",probe="2",continent="EU",country="DE",city="Nuremberg",asn="0",network="Hetzner Online GmbH"}`
+	expected := `# HELP globalping_ping_packets_sent_total Number of packets sent by the probe.
+# TYPE globalping_ping_packets_sent_total counter
+globalping_ping_packets_sent_total` + london + ` 1
+globalping_ping_packets_sent_total` + falkenstein + ` 1
+globalping_ping_packets_sent_total` + nuremberg + ` 1
+# HELP globalping_ping_packets_received_total Number of packets received by the probe.
+# TYPE globalping_ping_packets_received_total counter
+globalping_ping_packets_received_total` + london + ` 1
+globalping_ping_packets_received_total` + falkenstein + ` 1
+globalping_ping_packets_received_total` + nuremberg + ` 0
+# HELP globalping_ping_packet_loss_percent Percentage of packets lost.
+# TYPE globalping_ping_packet_loss_percent gauge
+globalping_ping_packet_loss_percent` + london + ` 0
+globalping_ping_packet_loss_percent` + falkenstein + ` 0
+globalping_ping_packet_loss_percent` + nuremberg + ` 100
+# HELP globalping_ping_rtt_last_seconds Round-trip time of the last received packet.
+# TYPE globalping_ping_rtt_last_seconds gauge
+globalping_ping_rtt_last_seconds` + london + ` 0.00077
+globalping_ping_rtt_last_seconds` + falkenstein + ` 0.00546
+# HELP globalping_ping_rtt_min_seconds Minimum round-trip time.
+# TYPE globalping_ping_rtt_min_seconds gauge
+globalping_ping_rtt_min_seconds` + london + ` 0.00077
+globalping_ping_rtt_min_seconds` + falkenstein + ` 0.00546
+# HELP globalping_ping_rtt_avg_seconds Average round-trip time.
+# TYPE globalping_ping_rtt_avg_seconds gauge
+globalping_ping_rtt_avg_seconds` + london + ` 0.00077
+globalping_ping_rtt_avg_seconds` + falkenstein + ` 0.00546
+# HELP globalping_ping_rtt_max_seconds Maximum round-trip time.
+# TYPE globalping_ping_rtt_max_seconds gauge
+globalping_ping_rtt_max_seconds` + london + ` 0.00077
+globalping_ping_rtt_max_seconds` + falkenstein + ` 0.00546
+# HELP globalping_ping_rtt_mdev_seconds Mean deviation of the round-trip time.
+# TYPE globalping_ping_rtt_mdev_seconds gauge
+globalping_ping_rtt_mdev_seconds` + london + ` 0
+globalping_ping_rtt_mdev_seconds` + falkenstein + ` 0
+`
+	assert.Equal(t, expected, string(ctx.Metrics.Bytes()))
+
+	rec := httptest.NewRecorder()
+	ctx.Metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, expected, rec.Body.String())
+}
+
+func Test_OutputInfinite_Metrics_Latency(t *testing.T) {
+	ctx := createDefaultContext("ping")
+	ctx.Target = "cdn.jsdelivr.net"
+	ctx.ToLatency = true
+	ctx.Metrics = NewMetrics()
+	w := new(bytes.Buffer)
+	v := NewViewer(ctx, NewPrinter(nil, w, w), nil, nil)
+	err := v.OutputInfinite(context.Background(), createPingMeasurement_MultipleProbes(measurementID1))
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(ctx.Metrics.Bytes()), `globalping_ping_packets_sent_total{target="cdn.jsdelivr.net",probe="0",continent="EU",country="GB",city="London",asn="0",network="OVH SAS"} 1`)
+}
+
+func Test_Metrics_Push(t *testing.T) {
+	var (
+		method string
+		path   string
+		body   string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	metrics := NewMetrics()
+	metrics.update([]byte("globalping_ping_packets_sent_total 1\n"))
+
+	err := metrics.Push(context.Background(), server.URL+"/")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/metrics/job/globalping", path)
+	assert.Equal(t, "globalping_ping_packets_sent_total 1\n", body)
+
+	err = metrics.Push(context.Background(), server.URL+"/metrics/job/ping/instance/jump-host")
+	assert.NoError(t, err)
+	assert.Equal(t, "/metrics/job/ping/instance/jump-host", path)
+}
+
+func Test_Metrics_Push_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := NewMetrics().Push(context.Background(), server.URL)
+	assert.EqualError(t, err, "failed to push metrics: unexpected status 400 Bad Request")
+}
@@ -0,0 +1,290 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// A summary of a measurement rendered by the markdown and html formats
+type report struct {
+	Title     string // The command and target
+	ID        string
+	URL       string
+	CreatedAt string
+	Columns   []string
+	Rows      [][]string // One row per probe, the first two columns are the location and the status
+	Outputs   []reportOutput
+}
+
+type reportOutput struct {
+	Location  string
+	RawOutput string
+}
+
+func (v *viewer) buildReport(id string, data *globalping.Measurement) (*report, error) {
+	var (
+		columns   []string
+		summarize func(result *globalping.ProbeResult) ([]string, error)
+	)
+	switch v.ctx.Cmd {
+	case "ping":
+		columns = []string{"Loss", "Min", "Avg", "Max"}
+		summarize = reportPingSummary
+	case "dns":
+		columns = []string{"Status code", "Answers", "Time"}
+		summarize = reportDNSSummary
+	case "http":
+		columns = []string{"Status code", "Total", "DNS", "TCP", "TLS", "First byte", "Download"}
+		summarize = reportHTTPSummary
+	case "traceroute":
+		columns = []string{"Hops", "Min", "Avg", "Max"}
+		summarize = reportTracerouteSummary
+	case "mtr":
+		columns = []string{"Hops", "Loss", "Min", "Avg", "Max"}
+		summarize = reportMTRSummary
+	default:
+		return nil, errors.New("unexpected command for report output: " + v.ctx.Cmd)
+	}
+
+	createdAt := data.CreatedAt
+	t, err := time.Parse(time.RFC3339Nano, data.CreatedAt)
+	if err == nil {
+		createdAt = t.UTC().Format(time.DateTime) + " UTC"
+	}
+	r := &report{
+		Title:     v.ctx.Cmd + " " + data.Target,
+		ID:        id,
+		URL:       ShareURL + id,
+		CreatedAt: createdAt,
+		Columns:   append([]string{"Location", "Status"}, columns...),
+		Rows:      make([][]string, len(data.Results)),
+		Outputs:   make([]reportOutput, len(data.Results)),
+	}
+	for i := range data.Results {
+		result := &data.Results[i]
+		location := getLocationText(result)
+		row := []string{location, string(result.Result.Status)}
+		if result.Result.Status == globalping.StatusFinished {
+			values, err := summarize(&result.Result)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, values...)
+		} else {
+			row = append(row, make([]string, len(columns))...)
+		}
+		r.Rows[i] = row
+		r.Outputs[i] = reportOutput{Location: location, RawOutput: strings.TrimSpace(result.Result.RawOutput)}
+	}
+	return r, nil
+}
+
+func reportPingSummary(result *globalping.ProbeResult) ([]string, error) {
+	stats, err := globalping.DecodePingStats(result.StatsRaw)
+	if err != nil {
+		return nil, err
+	}
+	if stats.Rcv == 0 {
+		return []string{formatFloat(stats.Loss) + "%", "-", "-", "-"}, nil
+	}
+	return []string{formatFloat(stats.Loss) + "%", formatReportMs(stats.Min), formatReportMs(stats.Avg), formatReportMs(stats.Max)}, nil
+}
+
+func reportDNSSummary(result *globalping.ProbeResult) ([]string, error) {
+	summary, err := summarizeDNSResult(result)
+	if err != nil {
+		return nil, err
+	}
+	return []string{result.StatusCodeName, strings.Join(summary.Answers, ", "), formatReportMs(summary.Total)}, nil
+}
+
+func reportHTTPSummary(result *globalping.ProbeResult) ([]string, error) {
+	timings, err := globalping.DecodeHTTPTimings(result.TimingsRaw)
+	if err != nil {
+		return nil, err
+	}
+	values := []string{strconv.Itoa(result.StatusCode)}
+	for _, t := range []int{timings.Total, timings.DNS, timings.TCP, timings.TLS, timings.FirstByte, timings.Download} {
+		values = append(values, formatReportMs(float64(t)))
+	}
+	return values, nil
+}
+
+func reportTracerouteSummary(result *globalping.ProbeResult) ([]string, error) {
+	hops, err := globalping.DecodeTracerouteHops(result.HopsRaw)
+	if err != nil {
+		return nil, err
+	}
+	values := []string{strconv.Itoa(len(hops)), "-", "-", "-"}
+	if len(hops) == 0 || len(hops[len(hops)-1].Timings) == 0 {
+		return values, nil
+	}
+	timings := hops[len(hops)-1].Timings
+	minRTT, maxRTT, sum := timings[0].RTT, timings[0].RTT, 0.0
+	for i := range timings {
+		minRTT = min(minRTT, timings[i].RTT)
+		maxRTT = max(maxRTT, timings[i].RTT)
+		sum += timings[i].RTT
+	}
+	values[1] = formatReportMs(minRTT)
+	values[2] = formatReportMs(sum / float64(len(timings)))
+	values[3] = formatReportMs(maxRTT)
+	return values, nil
+}
+
+func reportMTRSummary(result *globalping.ProbeResult) ([]string, error) {
+	hops, err := globalping.DecodeMTRHops(result.HopsRaw)
+	if err != nil {
+		return nil, err
+	}
+	values := []string{strconv.Itoa(len(hops)), "-", "-", "-", "-"}
+	if len(hops) == 0 {
+		return values, nil
+	}
+	stats := &hops[len(hops)-1].Stats
+	values[1] = formatFloat(stats.Loss) + "%"
+	if stats.Rcv > 0 {
+		values[2] = formatReportMs(stats.Min)
+		values[3] = formatReportMs(stats.Avg)
+		values[4] = formatReportMs(stats.Max)
+	}
+	return values, nil
+}
+
+func formatReportMs(ms float64) string {
+	return formatFloat(ms) + " ms"
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\n", " ")
+
+// Outputs a markdown report of the measurement, with a summary table and the raw output of every probe in a collapsible section
+func (v *viewer) OutputMarkdown(id string, data *globalping.Measurement) error {
+	r, err := v.buildReport(id, data)
+	if err != nil {
+		return err
+	}
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "## %s\n\n", r.Title)
+	fmt.Fprintf(b, "- Measurement: [%s](%s)\n", r.ID, r.URL)
+	fmt.Fprintf(b, "- Time: %s\n", r.CreatedAt)
+	fmt.Fprintf(b, "- Probes: %d\n\n", len(r.Rows))
+
+	writeRow := func(cells []string) {
+		for _, c := range cells {
+			b.WriteString("| " + markdownCellReplacer.Replace(c) + " ")
+		}
+		b.WriteString("|\n")
+	}
+	writeRow(r.Columns)
+	separators := make([]string, len(r.Columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeRow(separators)
+	for _, row := range r.Rows {
+		writeRow(row)
+	}
+
+	for _, o := range r.Outputs {
+		b.WriteString("\n<details>\n")
+		fmt.Fprintf(b, "<summary>%s</summary>\n\n", html.EscapeString(o.Location))
+		fence := markdownFence(o.RawOutput)
+		b.WriteString(fence + "\n" + o.RawOutput + "\n" + fence + "\n\n")
+		b.WriteString("</details>\n")
+	}
+	v.printer.Println(b.String())
+	return nil
+}
+
+// Returns a code fence longer than any run of backticks in s, so the fence can't be closed by the content
+func markdownFence(s string) string {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+const htmlReportHeader = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Globalping report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #1f2328; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d0d7de; padding: 4px 10px; text-align: left; }
+th { background: #f6f8fa; }
+pre { background: #f6f8fa; padding: 1em; overflow-x: auto; }
+summary { cursor: pointer; margin: 0.5em 0; }
+</style>
+</head>
+<body>`
+
+const htmlReportFooter = `</body>
+</html>`
+
+// Outputs a self-contained html report of the measurement, with a summary table and the raw output of every probe in a collapsible section.
+// The document header is only printed once, so multiple measurements form a single document closed by CloseHTMLReport.
+func (v *viewer) OutputHTML(id string, data *globalping.Measurement) error {
+	r, err := v.buildReport(id, data)
+	if err != nil {
+		return err
+	}
+	b := &strings.Builder{}
+	if !v.ctx.IsHTMLReportOpen {
+		v.ctx.IsHTMLReportOpen = true
+		b.WriteString(htmlReportHeader + "\n")
+	}
+	b.WriteString("<section>\n")
+	fmt.Fprintf(b, "<h2>%s</h2>\n", html.EscapeString(r.Title))
+	b.WriteString("<ul>\n")
+	fmt.Fprintf(b, "<li>Measurement: <a href=\"%s\">%s</a></li>\n", html.EscapeString(r.URL), html.EscapeString(r.ID))
+	fmt.Fprintf(b, "<li>Time: %s</li>\n", html.EscapeString(r.CreatedAt))
+	fmt.Fprintf(b, "<li>Probes: %d</li>\n", len(r.Rows))
+	b.WriteString("</ul>\n")
+
+	b.WriteString("<table>\n<tr>")
+	for _, c := range r.Columns {
+		b.WriteString("<th>" + html.EscapeString(c) + "</th>")
+	}
+	b.WriteString("</tr>\n")
+	for _, row := range r.Rows {
+		b.WriteString("<tr>")
+		for _, c := range row {
+			b.WriteString("<td>" + html.EscapeString(c) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n")
+
+	for _, o := range r.Outputs {
+		b.WriteString("<details>\n")
+		fmt.Fprintf(b, "<summary>%s</summary>\n", html.EscapeString(o.Location))
+		fmt.Fprintf(b, "<pre>%s</pre>\n", html.EscapeString(o.RawOutput))
+		b.WriteString("</details>\n")
+	}
+	b.WriteString("</section>")
+	v.printer.Println(b.String())
+	return nil
+}
+
+// Prints the closing tags of the html report once all measurements were output, if a report was started
+func CloseHTMLReport(ctx *Context, printer *Printer) {
+	if !ctx.IsHTMLReportOpen {
+		return
+	}
+	ctx.IsHTMLReportOpen = false
+	printer.Println(htmlReportFooter)
+}
@@ -0,0 +1,179 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_Markdown_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Results = append(measurement.Results, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", State: "NY", City: "New York", ASN: 567, Network: "Network | Inc."},
+		Result: globalping.ProbeResult{
+			Status:    globalping.StatusFailed,
+			RawOutput: "ping: cdn.jsdelivr.net: Name or service not known",
+		},
+	})
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:    "ping",
+		Format: FormatMarkdown,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "## ping cdn.jsdelivr.net\n\n"+
+		"- Measurement: ["+measurementID1+"](https://www.jsdelivr.com/globalping?measurement="+measurementID1+")\n"+
+		"- Time: 2024-01-18 14:09:41 UTC\n"+
+		"- Probes: 2\n\n"+
+		"| Location | Status | Loss | Min | Avg | Max |\n"+
+		"| --- | --- | --- | --- | --- | --- |\n"+
+		"| Berlin, DE, EU, Deutsche Telekom AG (AS3320) | finished | 0% | 17.639 ms | 17.639 ms | 17.639 ms |\n"+
+		"| New York (NY), US, NA, Network \\| Inc. (AS567) | failed |  |  |  |  |\n"+
+		"\n<details>\n"+
+		"<summary>Berlin, DE, EU, Deutsche Telekom AG (AS3320)</summary>\n\n"+
+		"```\n"+
+		"PING jsdelivr.map.fastly.net (151.101.1.229) 56(84) bytes of data.\n"+
+		"64 bytes from 151.101.1.229 (151.101.1.229): icmp_seq=1 ttl=60 time=17.6 ms\n\n"+
+		"--- jsdelivr.map.fastly.net ping statistics ---\n"+
+		"1 packets transmitted, 1 received, 0% packet loss, time 1000ms\n"+
+		"rtt min/avg/max/mdev = 17.639/17.639/17.639/0.123 ms\n"+
+		"```\n\n"+
+		"</details>\n"+
+		"\n<details>\n"+
+		"<summary>New York (NY), US, NA, Network | Inc. (AS567)</summary>\n\n"+
+		"```\n"+
+		"ping: cdn.jsdelivr.net: Name or service not known\n"+
+		"```\n\n"+
+		"</details>\n\n", w.String())
+}
+
+func Test_Output_HTML_HTTP(t *testing.T) {
+	measurement := &globalping.Measurement{
+		ID:        measurementID1,
+		Target:    "jsdelivr.com",
+		CreatedAt: "2024-01-18T14:09:41.250Z",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network & Co"},
+			Result: globalping.ProbeResult{
+				Status:     globalping.StatusFinished,
+				RawOutput:  "HTTP/1.1 200\n<html>",
+				StatusCode: 200,
+				TimingsRaw: json.RawMessage(`{"total":583,"download":18,"firstByte":450,"dns":24,"tls":70,"tcp":19}`),
+			},
+		}},
+	}
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "http", Format: FormatHTML}, printer: NewPrinter(nil, w, w)}
+
+	err := viewer.OutputHTML(measurementID1, measurement)
+	assert.NoError(t, err)
+	err = viewer.OutputHTML(measurementID1, measurement)
+	assert.NoError(t, err)
+
+	section := `<section>
+<h2>http jsdelivr.com</h2>
+<ul>
+<li>Measurement: <a href="https://www.jsdelivr.com/globalping?measurement=` + measurementID1 + `">` + measurementID1 + `</a></li>
+<li>Time: 2024-01-18 14:09:41 UTC</li>
+<li>Probes: 1</li>
+</ul>
+<table>
+<tr><th>Location</th><th>Status</th><th>Status code</th><th>Total</th><th>DNS</th><th>TCP</th><th>TLS</th><th>First byte</th><th>Download</th></tr>
+<tr><td>Berlin, DE, EU, Network &amp; Co (AS123)</td><td>finished</td><td>200</td><td>583 ms</td><td>24 ms</td><td>19 ms</td><td>70 ms</td><td>450 ms</td><td>18 ms</td></tr>
+</table>
+<details>
+<summary>Berlin, DE, EU, Network &amp; Co (AS123)</summary>
+<pre>HTTP/1.1 200
+&lt;html&gt;</pre>
+</details>
+</section>
+`
+	// The document header is only printed once
+	assert.Equal(t, htmlReportHeader+"\n"+section+section, w.String())
+
+	// The document is closed once the output ends
+	w.Reset()
+	CloseHTMLReport(viewer.ctx, viewer.printer)
+	assert.Equal(t, "</body>\n</html>\n", w.String())
+	assert.False(t, viewer.ctx.IsHTMLReportOpen)
+
+	w.Reset()
+	CloseHTMLReport(viewer.ctx, viewer.printer)
+	assert.Equal(t, "", w.String())
+}
+
+func Test_Output_Markdown_Fence(t *testing.T) {
+	measurement := &globalping.Measurement{
+		ID:        measurementID1,
+		Target:    "jsdelivr.com",
+		CreatedAt: "2024-01-18T14:09:41.250Z",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+			Result: globalping.ProbeResult{
+				Status:    globalping.StatusFailed,
+				RawOutput: "```\n``code`` ````",
+			},
+		}},
+	}
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "http", Format: FormatMarkdown}, printer: NewPrinter(nil, w, w)}
+
+	err := viewer.OutputMarkdown(measurementID1, measurement)
+	assert.NoError(t, err)
+	// The fence is longer than the backticks of the output
+	assert.Contains(t, w.String(), "\n`````\n```\n``code`` ````\n`````\n")
+
+	assert.Equal(t, "```", markdownFence("no backticks"))
+	assert.Equal(t, "```", markdownFence("`a` ``b``"))
+	assert.Equal(t, "````", markdownFence("```"))
+}
+
+func Test_Output_Markdown_Traceroute_MTR(t *testing.T) {
+	probe := globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"}
+	traceroute := &globalping.Measurement{
+		Target: "1.1.1.1",
+		Results: []globalping.ProbeMeasurement{{
+			Probe:  probe,
+			Result: globalping.ProbeResult{Status: globalping.StatusFinished, HopsRaw: testTracerouteHops},
+		}},
+	}
+	mtr := &globalping.Measurement{
+		Target: "1.1.1.1",
+		Results: []globalping.ProbeMeasurement{{
+			Probe:  probe,
+			Result: globalping.ProbeResult{Status: globalping.StatusFinished, HopsRaw: testMTRHops},
+		}},
+	}
+
+	ctx := &Context{Cmd: "traceroute", Format: FormatMarkdown}
+	viewer := &viewer{ctx: ctx}
+
+	r, err := viewer.buildReport(measurementID1, traceroute)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Location", "Status", "Hops", "Min", "Avg", "Max"}, r.Columns)
+	assert.Equal(t, [][]string{{"Berlin, DE, EU, Network 1 (AS123)", "finished", "3", "10.5 ms", "11.5 ms", "12.5 ms"}}, r.Rows)
+
+	ctx.Cmd = "mtr"
+	r, err = viewer.buildReport(measurementID2, mtr)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Location", "Status", "Hops", "Loss", "Min", "Avg", "Max"}, r.Columns)
+	assert.Equal(t, [][]string{{"Berlin, DE, EU, Network 1 (AS123)", "finished", "3", "33.3%", "10.5 ms", "11.5 ms", "12.5 ms"}}, r.Rows)
+}
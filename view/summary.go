@@ -6,6 +6,16 @@ import (
 )
 
 func (v *viewer) OutputSummary() {
+	if len(v.timingStats) > 0 {
+		v.outputTimingsSummary()
+		v.outputShareSummary()
+		return
+	}
+	if len(v.mtrStats) > 0 {
+		// The merged hops tables are already up to date
+		v.outputShareSummary()
+		return
+	}
 	if len(v.ctx.AggregatedStats) == 0 {
 		return
 	}
@@ -37,17 +47,27 @@ func (v *viewer) OutputSummary() {
 			mdev = fmt.Sprintf("%.3f", stats.Mdev)
 		}
 		v.printer.Printf("rtt min/avg/max/mdev = %s/%s/%s/%s ms\n", min, avg, max, mdev)
+	} else if v.ctx.Share && v.ctx.History != nil {
+		v.printer.Println() // Add a newline in table view
 	}
 
-	if v.ctx.Share && v.ctx.History != nil {
-		if len(v.ctx.AggregatedStats) > 1 {
-			v.printer.Println() // Add a newline in table view
-		}
-		ids := v.ctx.History.ToString("+")
-		if ids != "" {
-			v.printer.Println(v.getShareMessage(ids))
-		}
-		if v.ctx.MeasurementsCreated > v.ctx.History.Capacity() {
+	v.outputShareSummary()
+}
+
+// Outputs the link to the last measurements of a continuous measurement
+func (v *viewer) outputShareSummary() {
+	if !v.ctx.Share || v.ctx.History == nil {
+		return
+	}
+	ids := v.ctx.History.ToString("+")
+	if ids != "" {
+		v.printer.Println(v.getShareMessage(ids))
+	}
+	if v.ctx.MeasurementsCreated > v.ctx.History.Capacity() {
+		if len(v.timingStats) > 0 || len(v.mtrStats) > 0 {
+			v.printer.Printf("For long-running continuous mode measurements, only the last %d measurements are shared.\n",
+				v.ctx.History.Capacity())
+		} else {
 			v.printer.Printf("For long-running continuous mode measurements, only the last %d packets are shared.\n",
 				v.ctx.Packets*v.ctx.History.Capacity())
 		}
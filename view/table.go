@@ -0,0 +1,103 @@
+package view
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/mattn/go-runewidth"
+)
+
+// Outputs the structured results of a measurement as tables
+func (v *viewer) OutputTable(id string, data *globalping.Measurement) error {
+	var err error
+	switch v.ctx.Cmd {
+	case "dns":
+		err = v.outputDNSTable(data)
+	case "traceroute":
+		err = v.outputTracerouteTable(data)
+	case "mtr":
+		err = v.outputMTRTable(data)
+	default:
+		return errors.New("unexpected command for table output: " + v.ctx.Cmd)
+	}
+	if err != nil {
+		return err
+	}
+
+	if v.ctx.Share {
+		v.printer.Println(v.getShareMessage(id))
+	}
+	v.printer.Println()
+
+	return nil
+}
+
+// Returns the rows with aligned columns, the first row is used as the header
+func (v *viewer) formatTable(rows [][]string) string {
+	return v.formatTableWithWidths(rows, columnWidths(rows))
+}
+
+// Returns the width of every column, used to align the columns of multiple tables
+func columnWidths(tables ...[][]string) []int {
+	widths := []int{}
+	for _, rows := range tables {
+		for _, row := range rows {
+			for i, col := range row {
+				if i == len(widths) {
+					widths = append(widths, 0)
+				}
+				widths[i] = max(widths[i], runewidth.StringWidth(col))
+			}
+		}
+	}
+	return widths
+}
+
+func (v *viewer) formatTableWithWidths(rows [][]string, widths []int) string {
+	output := &strings.Builder{}
+	for i, row := range rows {
+		line := &strings.Builder{}
+		for j, col := range row {
+			if j == len(row)-1 {
+				line.WriteString(col)
+				break
+			}
+			line.WriteString(runewidth.FillRight(col, widths[j]+2))
+		}
+		text := strings.TrimRight(line.String(), " ")
+		if i == 0 && !v.ctx.CIMode {
+			text = v.printer.Bold(text)
+		}
+		output.WriteString(text + "\n")
+	}
+	return output.String()
+}
+
+// Returns the value shared by most probes, keyed by result index, and whether some probes have a different value
+func mostCommon(values map[int]string) (string, bool) {
+	indexes := make([]int, 0, len(values))
+	counts := make(map[string]int, len(values))
+	for i, value := range values {
+		indexes = append(indexes, i)
+		counts[value]++
+	}
+	// Break ties by using the value of the first probe
+	slices.Sort(indexes)
+	common, commonCount := "", 0
+	for _, i := range indexes {
+		value := values[i]
+		if counts[value] > commonCount {
+			common, commonCount = value, counts[value]
+		}
+	}
+	return common, len(counts) > 1
+}
+
+func (v *viewer) highlight(s string) string {
+	if v.ctx.CIMode {
+		return s
+	}
+	return v.printer.BoldWithColor(s, ColorLightCyan)
+}
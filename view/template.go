@@ -0,0 +1,139 @@
+package view
+
+import (
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// The data a template is executed against: the measurement with the decoded results of every probe
+type templateMeasurement struct {
+	globalping.Measurement
+	Results []templateResult
+}
+
+// The result of a probe, with the stats, timings, answers, hops, headers and TLS details decoded for the measurement type.
+// The decoded fields hold zero values if the probe didn't return them, e.g. if it failed.
+type templateResult struct {
+	globalping.ProbeResult
+	Probe   globalping.ProbeDetails
+	Stats   globalping.PingStats   // The ping stats
+	Timings any                    // []globalping.PingTiming for ping, globalping.DNSTimings for dns and globalping.HTTPTimings for http
+	Answers []globalping.DNSAnswer // The dns answers, the ones of the last resolver with the trace option
+	Hops    any                    // []globalping.TracerouteHop, []globalping.MTRHop or []globalping.DNSTraceHop with the dns trace option
+	Headers globalping.HTTPHeaders // The http response headers
+	TLS     *globalping.HTTPTLS    // The http TLS certificate, nil if the connection wasn't secure
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"join": strings.Join,
+}
+
+// Parses a template set with --template or --template-file, with the json and join functions available
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("output").Funcs(templateFuncs).Parse(text)
+}
+
+// Outputs the measurement using the template set with --template or --template-file
+func (v *viewer) OutputTemplate(data *globalping.Measurement) error {
+	m := &templateMeasurement{
+		Measurement: *data,
+		Results:     make([]templateResult, len(data.Results)),
+	}
+	for i := range data.Results {
+		result, err := v.newTemplateResult(&data.Results[i])
+		if err != nil {
+			return err
+		}
+		m.Results[i] = *result
+	}
+	b := &strings.Builder{}
+	err := v.ctx.Template.Execute(b, m)
+	if err != nil {
+		return err
+	}
+	v.printer.Print(b.String())
+	return nil
+}
+
+func (v *viewer) newTemplateResult(pm *globalping.ProbeMeasurement) (*templateResult, error) {
+	raw := &pm.Result
+	r := &templateResult{
+		ProbeResult: pm.Result,
+		Probe:       pm.Probe,
+		Answers:     []globalping.DNSAnswer{},
+	}
+	var err error
+	switch v.ctx.Cmd {
+	case "ping":
+		r.Timings = []globalping.PingTiming{}
+		if len(raw.StatsRaw) > 0 {
+			stats, err := globalping.DecodePingStats(raw.StatsRaw)
+			if err != nil {
+				return nil, err
+			}
+			r.Stats = *stats
+		}
+		if len(raw.TimingsRaw) > 0 {
+			r.Timings, err = globalping.DecodePingTimings(raw.TimingsRaw)
+		}
+	case "dns":
+		r.Timings = globalping.DNSTimings{}
+		r.Hops = []globalping.DNSTraceHop{}
+		if raw.Status != globalping.StatusFinished {
+			break
+		}
+		hops, err := decodeDNSHops(raw)
+		if err != nil {
+			return nil, err
+		}
+		timings := globalping.DNSTimings{}
+		for i := range hops {
+			timings.Total += hops[i].Timings.Total
+		}
+		r.Timings = timings
+		if len(hops) > 0 {
+			r.Answers = hops[len(hops)-1].Answers
+		}
+		if len(raw.HopsRaw) > 0 {
+			r.Hops = hops
+		}
+	case "http":
+		r.Timings = globalping.HTTPTimings{}
+		r.Headers = globalping.HTTPHeaders{}
+		if len(raw.TimingsRaw) > 0 {
+			timings, err := globalping.DecodeHTTPTimings(raw.TimingsRaw)
+			if err != nil {
+				return nil, err
+			}
+			r.Timings = *timings
+		}
+		if len(raw.HeadersRaw) > 0 {
+			r.Headers, err = globalping.DecodeHTTPHeaders(raw.HeadersRaw)
+			if err != nil {
+				return nil, err
+			}
+		}
+		r.TLS, err = globalping.DecodeHTTPTLS(raw.TLSRaw)
+	case "traceroute":
+		r.Hops = []globalping.TracerouteHop{}
+		if len(raw.HopsRaw) > 0 {
+			r.Hops, err = globalping.DecodeTracerouteHops(raw.HopsRaw)
+		}
+	case "mtr":
+		r.Hops = []globalping.MTRHop{}
+		if len(raw.HopsRaw) > 0 {
+			r.Hops, err = globalping.DecodeMTRHops(raw.HopsRaw)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
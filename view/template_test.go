@@ -0,0 +1,112 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_Template_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createPingMeasurement(measurementID1)
+	measurement.Results = append(measurement.Results, globalping.ProbeMeasurement{
+		Probe: globalping.ProbeDetails{City: "Warsaw"},
+		Result: globalping.ProbeResult{
+			Status:    globalping.StatusFailed,
+			RawOutput: "ping: cdn.jsdelivr.net: Name or service not known",
+		},
+	})
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	tmpl, err := ParseTemplate(`{{.Target}}
+{{range .Results}}{{.Probe.City}} {{.Status}} {{.Stats.Avg}} {{json .Timings}}
+{{end}}`)
+	assert.NoError(t, err)
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:      "ping",
+		Format:   FormatTemplate,
+		Template: tmpl,
+	}, NewPrinter(nil, w, w), nil, gbMock)
+
+	err = viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+	assert.Equal(t, `cdn.jsdelivr.net
+Berlin finished 17.639 [{"rtt":17.639,"ttl":60}]
+Warsaw failed 0 []
+`, w.String())
+}
+
+func Test_Output_Template_HTTP(t *testing.T) {
+	measurement := &globalping.Measurement{
+		Target: "jsdelivr.com",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: globalping.ProbeDetails{City: "Berlin"},
+			Result: globalping.ProbeResult{
+				Status:     globalping.StatusFinished,
+				StatusCode: 200,
+				TimingsRaw: json.RawMessage(`{"total":583,"download":18,"firstByte":450,"dns":24,"tls":70,"tcp":19}`),
+				HeadersRaw: json.RawMessage(`{"content-type":"text/html","vary":["Accept-Encoding","Origin"]}`),
+			},
+		}},
+	}
+
+	tmpl, err := ParseTemplate(`{{range .Results}}{{.StatusCode}} {{.Timings.Total}} {{.Headers.Get "Content-Type"}} {{join (index .Headers "vary") ","}} {{.TLS}}{{end}}`)
+	assert.NoError(t, err)
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "http", Template: tmpl}, printer: NewPrinter(nil, w, w)}
+
+	err = viewer.OutputTemplate(measurement)
+	assert.NoError(t, err)
+	assert.Equal(t, "200 583 text/html Accept-Encoding,Origin <nil>", w.String())
+}
+
+func Test_Output_Template_DNS(t *testing.T) {
+	measurement := &globalping.Measurement{
+		Target: "jsdelivr.com",
+		Results: []globalping.ProbeMeasurement{{
+			Probe: globalping.ProbeDetails{City: "Berlin"},
+			Result: globalping.ProbeResult{
+				Status:         globalping.StatusFinished,
+				StatusCodeName: "NOERROR",
+				Resolver:       "1.1.1.1",
+				TimingsRaw:     json.RawMessage(`{"total":15}`),
+				AnswersRaw:     json.RawMessage(`[{"name":"jsdelivr.com.","type":"A","ttl":30,"class":"IN","value":"104.16.85.20"}]`),
+			},
+		}},
+	}
+
+	tmpl, err := ParseTemplate(`{{range .Results}}{{.StatusCodeName}} {{.Timings.Total}}{{range .Answers}} {{.Type}} {{.Value}}{{end}} {{len .Hops}}{{end}}`)
+	assert.NoError(t, err)
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "dns", Template: tmpl}, printer: NewPrinter(nil, w, w)}
+
+	err = viewer.OutputTemplate(measurement)
+	assert.NoError(t, err)
+	assert.Equal(t, "NOERROR 15 A 104.16.85.20 0", w.String())
+}
+
+func Test_Output_Template_Execute_Error(t *testing.T) {
+	tmpl, err := ParseTemplate(`{{range .Results}}{{.Unknown}}{{end}}`)
+	assert.NoError(t, err)
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{ctx: &Context{Cmd: "ping", Template: tmpl}, printer: NewPrinter(nil, w, w)}
+
+	err = viewer.OutputTemplate(createPingMeasurement(measurementID1))
+	assert.ErrorContains(t, err, "can't evaluate field Unknown")
+	assert.Equal(t, "", w.String())
+}
@@ -0,0 +1,127 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+)
+
+// Certificates expiring within this duration are flagged in the TLS output
+var CertificateExpiryWarning = 30 * 24 * time.Hour
+
+// Outputs the response status and TLS certificate details of an http measurement,
+// flagging certificates expiring soon and probes which received a different certificate than most of the others
+func (v *viewer) OutputTLS(id string, data *globalping.Measurement) error {
+	certs := make([]*globalping.HTTPTLS, len(data.Results))
+	fingerprints := make(map[int]string, len(data.Results))
+	for i := range data.Results {
+		result := &data.Results[i].Result
+		if result.Status != globalping.StatusFinished {
+			continue
+		}
+		cert, err := globalping.DecodeHTTPTLS(result.TLSRaw)
+		if err != nil {
+			return err
+		}
+		if cert != nil {
+			certs[i] = cert
+			fingerprints[i] = certificateFingerprint(cert)
+		}
+	}
+	expectedFingerprint, hasDifferences := mostCommon(fingerprints)
+
+	now := v.time.Now()
+	for i := range data.Results {
+		result := &data.Results[i]
+		if i > 0 {
+			// new line as separator if more than 1 result
+			v.printer.Println()
+		}
+
+		probeInfo := v.getProbeInfo(result)
+		cert := certs[i]
+		if hasDifferences && cert != nil && fingerprints[i] != expectedFingerprint {
+			probeInfo += " " + v.highlight("(different certificate)")
+		}
+		if cert != nil {
+			if !cert.ExpiresAt.After(now) {
+				probeInfo += " " + v.highlight("(expired)")
+			} else if cert.ExpiresAt.Sub(now) <= CertificateExpiryWarning {
+				probeInfo += " " + v.highlight("(expires soon)")
+			}
+		}
+		if result.Result.Status == globalping.StatusInProgress {
+			probeInfo += " (timed out)"
+		}
+		v.printer.Println(probeInfo)
+
+		if result.Result.Status != globalping.StatusFinished {
+			v.printer.Println(strings.TrimSpace(result.Result.RawOutput))
+			continue
+		}
+		status := "Status: " + strconv.Itoa(result.Result.StatusCode)
+		if result.Result.StatusCodeName != "" {
+			status += " " + result.Result.StatusCodeName
+		}
+		v.printer.Println(status)
+		if result.Result.ResolvedAddress != "" {
+			v.printer.Println("Address: " + result.Result.ResolvedAddress)
+		}
+		if cert == nil {
+			v.printer.Println("No TLS connection")
+			continue
+		}
+		v.printer.Println(fmt.Sprintf("Protocol: %s, Cipher: %s", cert.Protocol, cert.CipherName))
+		v.printer.Println("Subject: " + cert.Subject.CommonName)
+		if cert.Subject.AltNames != "" {
+			v.printer.Println("Alt names: " + cert.Subject.AltNames)
+		}
+		v.printer.Println("Issuer: " + formatIssuer(&cert.Issuer))
+		v.printer.Println(fmt.Sprintf("Valid: %s - %s (%s)",
+			cert.CreatedAt.UTC().Format(time.DateTime),
+			cert.ExpiresAt.UTC().Format(time.DateTime),
+			formatExpiry(cert.ExpiresAt.Sub(now)),
+		))
+		if cert.Authorized {
+			v.printer.Println("Authorized: yes")
+		} else {
+			v.printer.Println("Authorized: no (" + cert.Error + ")")
+		}
+	}
+
+	if v.ctx.Share {
+		v.printer.Println(v.getShareMessage(id))
+	}
+	v.printer.Println()
+
+	return nil
+}
+
+// Returns a value identifying the certificate
+func certificateFingerprint(cert *globalping.HTTPTLS) string {
+	if cert.Fingerprint256 != "" {
+		return cert.Fingerprint256
+	}
+	return cert.SerialNumber + "|" + cert.Subject.CommonName + "|" + cert.ExpiresAt.String()
+}
+
+func formatIssuer(issuer *globalping.HTTPTLSIssuer) string {
+	parts := make([]string, 0, 3)
+	for _, p := range []string{issuer.CommonName, issuer.Organization, issuer.Country} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatExpiry(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	if d <= 0 {
+		return fmt.Sprintf("expired %d days ago", -days)
+	}
+	return fmt.Sprintf("expires in %d days", days)
+}
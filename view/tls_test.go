@@ -0,0 +1,187 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/globalping"
+	"github.com/jsdelivr/globalping-cli/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Output_TLS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	validCert := json.RawMessage(`{
+		"authorized": true,
+		"protocol": "TLSv1.3",
+		"cipherName": "TLS_AES_256_GCM_SHA384",
+		"createdAt": "2023-11-01T00:00:00.000Z",
+		"expiresAt": "2024-06-01T00:00:00.000Z",
+		"subject": {"CN": "jsdelivr.com", "alt": "DNS:jsdelivr.com, DNS:www.jsdelivr.com"},
+		"issuer": {"C": "GB", "O": "Sectigo Limited", "CN": "Sectigo RSA Domain Validation Secure Server CA"},
+		"fingerprint256": "AA:BB"
+	}`)
+	measurement := &globalping.Measurement{
+		Status: globalping.StatusFinished,
+		Results: []globalping.ProbeMeasurement{
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+				Result: globalping.ProbeResult{
+					Status:          globalping.StatusFinished,
+					StatusCode:      200,
+					StatusCodeName:  "OK",
+					ResolvedAddress: "1.2.3.4",
+					TLSRaw:          validCert,
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "PL", City: "Warsaw", ASN: 456, Network: "Network 2"},
+				Result: globalping.ProbeResult{
+					Status:          globalping.StatusFinished,
+					StatusCode:      200,
+					StatusCodeName:  "OK",
+					ResolvedAddress: "1.2.3.4",
+					TLSRaw:          validCert,
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "NA", Country: "US", City: "Miami", ASN: 789, Network: "Network 3"},
+				Result: globalping.ProbeResult{
+					Status:          globalping.StatusFinished,
+					StatusCode:      200,
+					StatusCodeName:  "OK",
+					ResolvedAddress: "5.6.7.8",
+					TLSRaw: json.RawMessage(`{
+						"authorized": false,
+						"error": "CERT_HAS_EXPIRED",
+						"protocol": "TLSv1.2",
+						"cipherName": "ECDHE-RSA-AES128-GCM-SHA256",
+						"createdAt": "2023-01-01T00:00:00.000Z",
+						"expiresAt": "2023-12-27T00:00:00.000Z",
+						"subject": {"CN": "jsdelivr.com"},
+						"issuer": {"O": "Let's Encrypt", "CN": "R3"},
+						"fingerprint256": "CC:DD"
+					}`),
+				},
+			},
+		},
+	}
+
+	gbMock := mocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Times(1).Return(measurement, nil)
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	viewer := NewViewer(&Context{
+		Cmd:    "http",
+		CIMode: true,
+		TLS:    true,
+	}, NewPrinter(nil, w, w), timeMock, gbMock)
+
+	err := viewer.Output(context.Background(), measurementID1, &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123)
+Status: 200 OK
+Address: 1.2.3.4
+Protocol: TLSv1.3, Cipher: TLS_AES_256_GCM_SHA384
+Subject: jsdelivr.com
+Alt names: DNS:jsdelivr.com, DNS:www.jsdelivr.com
+Issuer: Sectigo RSA Domain Validation Secure Server CA, Sectigo Limited, GB
+Valid: 2023-11-01 00:00:00 - 2024-06-01 00:00:00 (expires in 152 days)
+Authorized: yes
+
+> Warsaw, PL, EU, Network 2 (AS456)
+Status: 200 OK
+Address: 1.2.3.4
+Protocol: TLSv1.3, Cipher: TLS_AES_256_GCM_SHA384
+Subject: jsdelivr.com
+Alt names: DNS:jsdelivr.com, DNS:www.jsdelivr.com
+Issuer: Sectigo RSA Domain Validation Secure Server CA, Sectigo Limited, GB
+Valid: 2023-11-01 00:00:00 - 2024-06-01 00:00:00 (expires in 152 days)
+Authorized: yes
+
+> Miami, US, NA, Network 3 (AS789) (different certificate) (expired)
+Status: 200 OK
+Address: 5.6.7.8
+Protocol: TLSv1.2, Cipher: ECDHE-RSA-AES128-GCM-SHA256
+Subject: jsdelivr.com
+Issuer: R3, Let's Encrypt
+Valid: 2023-01-01 00:00:00 - 2023-12-27 00:00:00 (expired 5 days ago)
+Authorized: no (CERT_HAS_EXPIRED)
+
+`, w.String())
+}
+
+func Test_Output_TLS_Expires_Soon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := &globalping.Measurement{
+		Results: []globalping.ProbeMeasurement{
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "DE", City: "Berlin", ASN: 123, Network: "Network 1"},
+				Result: globalping.ProbeResult{
+					Status:         globalping.StatusFinished,
+					StatusCode:     301,
+					StatusCodeName: "Moved Permanently",
+					TLSRaw: json.RawMessage(`{
+						"authorized": true,
+						"protocol": "TLSv1.3",
+						"cipherName": "TLS_AES_128_GCM_SHA256",
+						"createdAt": "2023-10-20T00:00:00.000Z",
+						"expiresAt": "2024-01-20T00:00:00.000Z",
+						"subject": {"CN": "example.com"},
+						"issuer": {"CN": "R3"}
+					}`),
+				},
+			},
+			{
+				Probe: globalping.ProbeDetails{Continent: "EU", Country: "PL", City: "Warsaw", ASN: 456, Network: "Network 2"},
+				Result: globalping.ProbeResult{
+					Status:         globalping.StatusFinished,
+					StatusCode:     200,
+					StatusCodeName: "OK",
+				},
+			},
+		},
+	}
+
+	timeMock := mocks.NewMockTime(ctrl)
+	timeMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	viewer := &viewer{
+		ctx: &Context{
+			Cmd:    "http",
+			CIMode: true,
+			TLS:    true,
+		},
+		printer: NewPrinter(nil, w, w),
+		time:    timeMock,
+	}
+
+	err := viewer.OutputTLS(measurementID1, measurement)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `> Berlin, DE, EU, Network 1 (AS123) (expires soon)
+Status: 301 Moved Permanently
+Protocol: TLSv1.3, Cipher: TLS_AES_128_GCM_SHA256
+Subject: example.com
+Issuer: R3
+Valid: 2023-10-20 00:00:00 - 2024-01-20 00:00:00 (expires in 19 days)
+Authorized: yes
+
+> Warsaw, PL, EU, Network 2 (AS456)
+Status: 200 OK
+No TLS connection
+
+`, w.String())
+}
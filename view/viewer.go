@@ -1,13 +1,15 @@
 package view
 
 import (
+	"context"
+
 	"github.com/jsdelivr/globalping-cli/globalping"
 	"github.com/jsdelivr/globalping-cli/utils"
 )
 
 type Viewer interface {
-	Output(id string, m *globalping.MeasurementCreate) error
-	OutputInfinite(m *globalping.Measurement) error
+	Output(ctx context.Context, id string, m *globalping.MeasurementCreate) error
+	OutputInfinite(ctx context.Context, m *globalping.Measurement) error
 	OutputSummary()
 }
 
@@ -16,6 +18,14 @@ type viewer struct {
 	printer    *Printer
 	time       utils.Time
 	globalping globalping.Client
+
+	csvHeader   string           // The last printed CSV header
+	junitSuites []junitTestSuite // The test suites of the measurements written to the JUnit report
+
+	ndjsonPackets map[string][]int // The number of packets output for every probe, by measurement in progress
+
+	timingStats []*probeTimingStats // The stats of every probe of a continuous dns or http measurement
+	mtrStats    [][]*mtrHopStats    // The merged hops of every probe of a continuous mtr measurement
 }
 
 func NewViewer(